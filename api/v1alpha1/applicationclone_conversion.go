@@ -0,0 +1,354 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/redhat-appstudio/clone-controller/api/v1beta1"
+)
+
+// ConversionAnnotation holds the fields of a v1beta1 ApplicationClone that v1alpha1 can't represent, so that they
+// survive a round trip through v1alpha1.
+const ConversionAnnotation = "appstudio.redhat.com/v1beta1-fields"
+
+// v1beta1Fields are the fields kept in ConversionAnnotation
+type v1beta1Fields struct {
+	// Conditions keeps the transition times of the conditions v1alpha1 flattens into Error
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ConvertTo converts this ApplicationClone to the hub version
+func (src *ApplicationClone) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.ApplicationClone)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	fields := v1beta1Fields{}
+	if data, ok := dst.Annotations[ConversionAnnotation]; ok {
+		if err := json.Unmarshal([]byte(data), &fields); err != nil {
+			return fmt.Errorf("error reading annotation %s: %w", ConversionAnnotation, err)
+		}
+		// metav1.Time reads timestamps in the local time zone
+		for i := range fields.Conditions {
+			fields.Conditions[i].LastTransitionTime = metav1.NewTime(fields.Conditions[i].LastTransitionTime.UTC())
+		}
+		delete(dst.Annotations, ConversionAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	dst.Spec = v1beta1.ApplicationCloneSpec{
+		Source:                      convertFromTo(src.Spec.From),
+		ServiceAccountName:          src.Spec.ServiceAccountName,
+		DefaultMode:                 v1beta1.ComponentMode(src.Spec.DefaultMode),
+		Snapshot:                    src.Spec.Snapshot,
+		Build:                       convertBuildSettingsTo(src.Spec.Build),
+		IntegrationTests:            (*v1beta1.IntegrationTestSelection)(src.Spec.IntegrationTests.DeepCopy()),
+		Environments:                convertEnvironmentCloningTo(src.Spec.Environments),
+		SnapshotEnvironmentBindings: (*v1beta1.SnapshotEnvironmentBindingCloning)(src.Spec.SnapshotEnvironmentBindings.DeepCopy()),
+		GitOps:                      (*v1beta1.GitOpsTarget)(src.Spec.GitOps.DeepCopy()),
+		DefaultSecretStrategy:       v1beta1.SecretStrategy(src.Spec.DefaultSecretStrategy),
+	}
+	for _, resource := range src.Spec.ExtraResources {
+		dst.Spec.ExtraResources = append(dst.Spec.ExtraResources, *(*v1beta1.ExtraResource)(resource.DeepCopy()))
+	}
+	for _, override := range src.Spec.ConfigMapOverrides {
+		dst.Spec.ConfigMapOverrides = append(dst.Spec.ConfigMapOverrides, *(*v1beta1.ConfigMapOverride)(override.DeepCopy()))
+	}
+	for _, secret := range src.Spec.Secrets {
+		dst.Spec.Secrets = append(dst.Spec.Secrets, v1beta1.SecretCloning{Name: secret.Name, Strategy: v1beta1.SecretStrategy(secret.Strategy)})
+	}
+	for _, rule := range src.Spec.Sanitization {
+		dst.Spec.Sanitization = append(dst.Spec.Sanitization, v1beta1.SanitizationRule{Name: rule.Name, Value: rule.Value, Action: v1beta1.SanitizationAction(rule.Action), Replacement: rule.Replacement})
+	}
+	for _, component := range src.Spec.ComponentSources {
+		mode := v1beta1.ComponentMode(component.Mode)
+		if mode == "" {
+			mode = v1beta1.ComponentModeSource
+		}
+		dst.Spec.Components = append(dst.Spec.Components, v1beta1.ComponentCloning{Name: component.Name, Mode: mode, Build: convertBuildSettingsTo(component.Build)})
+	}
+	for _, override := range src.Spec.IntegrationTestOverrides {
+		dst.Spec.IntegrationTestOverrides = append(dst.Spec.IntegrationTestOverrides, convertIntegrationTestOverrideTo(override))
+	}
+
+	dst.Status = v1beta1.ApplicationCloneStatus{
+		Conditions:      fields.Conditions,
+		LastAttemptTime: parseTime(src.Status.LastAttempt),
+		LastSuccessTime: parseTime(src.Status.LastSuccessfulAttempt),
+		Commit:          src.Status.Commit,
+		PendingSecrets:  append([]string(nil), src.Status.PendingSecrets...),
+	}
+	for _, resource := range src.Status.Resources {
+		dst.Status.Resources = append(dst.Status.Resources, v1beta1.Resource(resource))
+	}
+	for _, field := range src.Status.Sanitized {
+		dst.Status.Sanitized = append(dst.Status.Sanitized, v1beta1.SanitizedField{Component: field.Component, Env: field.Env, Action: v1beta1.SanitizationAction(field.Action)})
+	}
+	for _, violation := range src.Status.PolicyViolations {
+		dst.Status.PolicyViolations = append(dst.Status.PolicyViolations, v1beta1.PolicyViolation(violation))
+	}
+	if src.Status.Approval != nil {
+		dst.Status.Approval = &v1beta1.ApprovalRecord{Name: src.Status.Approval.Name, Approver: src.Status.Approval.Approver, ExpirationTime: src.Status.Approval.ExpirationTime}
+	}
+
+	// The Ready condition is derived from the error of the last attempt and the pending Secrets. Its transition
+	// time is kept as long as the outcome doesn't change.
+	ready := metav1.Condition{Type: v1beta1.ReadyCondition}
+	switch {
+	case src.Status.Error != "":
+		ready.Status, ready.Reason, ready.Message = metav1.ConditionFalse, v1beta1.CloneFailedReason, src.Status.Error
+		if len(src.Status.PolicyViolations) > 0 {
+			ready.Reason = v1beta1.PolicyViolationReason
+		}
+		if dst.Status.LastAttemptTime != nil {
+			ready.LastTransitionTime = *dst.Status.LastAttemptTime
+		}
+	case src.Status.PendingApproval:
+		ready.Status, ready.Reason = metav1.ConditionFalse, v1beta1.PendingApprovalReason
+		ready.Message = "Waiting for an ApplicationCloneApproval of the namespace of the Application"
+		if dst.Status.LastAttemptTime != nil {
+			ready.LastTransitionTime = *dst.Status.LastAttemptTime
+		}
+	case dst.Status.LastSuccessTime != nil && len(src.Status.PendingSecrets) > 0:
+		ready.Status, ready.Reason = metav1.ConditionFalse, v1beta1.SecretsPendingReason
+		ready.Message = "The Application was cloned, waiting for the values of the Secrets " + strings.Join(src.Status.PendingSecrets, ", ")
+		ready.LastTransitionTime = *dst.Status.LastSuccessTime
+	case dst.Status.LastSuccessTime != nil:
+		ready.Status, ready.Reason, ready.Message = metav1.ConditionTrue, v1beta1.ClonedReason, "The Application was cloned"
+		ready.LastTransitionTime = *dst.Status.LastSuccessTime
+	default:
+		meta.RemoveStatusCondition(&dst.Status.Conditions, v1beta1.ReadyCondition)
+		return nil
+	}
+	meta.SetStatusCondition(&dst.Status.Conditions, ready)
+
+	return nil
+}
+
+// ConvertFrom converts from the hub version to this ApplicationClone
+func (dst *ApplicationClone) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.ApplicationClone)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+
+	dst.Spec = ApplicationCloneSpec{
+		From:                        convertFromFrom(src.Spec.Source),
+		ServiceAccountName:          src.Spec.ServiceAccountName,
+		DefaultMode:                 ComponentMode(src.Spec.DefaultMode),
+		Snapshot:                    src.Spec.Snapshot,
+		Build:                       convertBuildSettingsFrom(src.Spec.Build),
+		IntegrationTests:            (*IntegrationTestSelection)(src.Spec.IntegrationTests.DeepCopy()),
+		Environments:                convertEnvironmentCloningFrom(src.Spec.Environments),
+		SnapshotEnvironmentBindings: (*SnapshotEnvironmentBindingCloning)(src.Spec.SnapshotEnvironmentBindings.DeepCopy()),
+		GitOps:                      (*GitOpsTarget)(src.Spec.GitOps.DeepCopy()),
+		DefaultSecretStrategy:       SecretStrategy(src.Spec.DefaultSecretStrategy),
+	}
+	for _, resource := range src.Spec.ExtraResources {
+		dst.Spec.ExtraResources = append(dst.Spec.ExtraResources, *(*ExtraResource)(resource.DeepCopy()))
+	}
+	for _, override := range src.Spec.ConfigMapOverrides {
+		dst.Spec.ConfigMapOverrides = append(dst.Spec.ConfigMapOverrides, *(*ConfigMapOverride)(override.DeepCopy()))
+	}
+	for _, secret := range src.Spec.Secrets {
+		dst.Spec.Secrets = append(dst.Spec.Secrets, SecretCloning{Name: secret.Name, Strategy: SecretStrategy(secret.Strategy)})
+	}
+	for _, rule := range src.Spec.Sanitization {
+		dst.Spec.Sanitization = append(dst.Spec.Sanitization, SanitizationRule{Name: rule.Name, Value: rule.Value, Action: SanitizationAction(rule.Action), Replacement: rule.Replacement})
+	}
+	// The modes are given explicitly, as they are once defaulted
+	for _, component := range src.Spec.Components {
+		dst.Spec.ComponentSources = append(dst.Spec.ComponentSources, ComponentSource{Name: component.Name, Mode: ComponentMode(component.Mode), Build: convertBuildSettingsFrom(component.Build)})
+	}
+	for _, override := range src.Spec.IntegrationTestOverrides {
+		dst.Spec.IntegrationTestOverrides = append(dst.Spec.IntegrationTestOverrides, convertIntegrationTestOverrideFrom(override))
+	}
+
+	dst.Status = ApplicationCloneStatus{
+		LastAttempt:           formatTime(src.Status.LastAttemptTime),
+		LastSuccessfulAttempt: formatTime(src.Status.LastSuccessTime),
+		Commit:                src.Status.Commit,
+		PendingSecrets:        append([]string(nil), src.Status.PendingSecrets...),
+	}
+	for _, resource := range src.Status.Resources {
+		dst.Status.Resources = append(dst.Status.Resources, Resource(resource))
+	}
+	for _, field := range src.Status.Sanitized {
+		dst.Status.Sanitized = append(dst.Status.Sanitized, SanitizedField{Component: field.Component, Env: field.Env, Action: SanitizationAction(field.Action)})
+	}
+	for _, violation := range src.Status.PolicyViolations {
+		dst.Status.PolicyViolations = append(dst.Status.PolicyViolations, PolicyViolation(violation))
+	}
+	if src.Status.Approval != nil {
+		dst.Status.Approval = &ApprovalRecord{Name: src.Status.Approval.Name, Approver: src.Status.Approval.Approver, ExpirationTime: src.Status.Approval.ExpirationTime}
+	}
+	if ready := meta.FindStatusCondition(src.Status.Conditions, v1beta1.ReadyCondition); ready != nil && ready.Status == metav1.ConditionFalse {
+		switch ready.Reason {
+		case v1beta1.SecretsPendingReason:
+		case v1beta1.PendingApprovalReason:
+			dst.Status.PendingApproval = true
+		default:
+			dst.Status.Error = ready.Message
+		}
+	}
+
+	// Keep what v1alpha1 can't represent
+	fields := v1beta1Fields{Conditions: src.Status.Conditions}
+	if fields.Conditions != nil {
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("error writing annotation %s: %w", ConversionAnnotation, err)
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[ConversionAnnotation] = string(data)
+	}
+
+	return nil
+}
+
+// convertFromTo converts From to the hub's ApplicationSource. A ClusterRef can't be combined with a bundle, and
+// is dropped in that case.
+func convertFromTo(from From) v1beta1.ApplicationSource {
+	if from == (From{}) {
+		return v1beta1.ApplicationSource{}
+	}
+	if from.Bundle != nil {
+		return v1beta1.ApplicationSource{
+			Bundle: &v1beta1.BundleReference{
+				Application: from.Name,
+				Namespace:   from.Namespace,
+				ConfigMap:   (*v1beta1.ConfigMapBundleSource)(from.Bundle.ConfigMap.DeepCopy()),
+				OCI:         (*v1beta1.OCIArtifact)(from.Bundle.OCI.DeepCopy()),
+			},
+		}
+	}
+	return v1beta1.ApplicationSource{
+		Application: &v1beta1.ApplicationReference{
+			Namespace: from.Namespace,
+			Name:      from.Name,
+			Cluster:   (*v1beta1.ClusterRef)(from.ClusterRef.DeepCopy()),
+		},
+	}
+}
+
+func convertFromFrom(source v1beta1.ApplicationSource) From {
+	switch {
+	case source.Bundle != nil:
+		return From{
+			Namespace: source.Bundle.Namespace,
+			Name:      source.Bundle.Application,
+			Bundle: &BundleSource{
+				ConfigMap: (*ConfigMapBundleSource)(source.Bundle.ConfigMap.DeepCopy()),
+				OCI:       (*OCIArtifact)(source.Bundle.OCI.DeepCopy()),
+			},
+		}
+	case source.Application != nil:
+		return From{
+			Namespace:  source.Application.Namespace,
+			Name:       source.Application.Name,
+			ClusterRef: (*ClusterRef)(source.Application.Cluster.DeepCopy()),
+		}
+	default:
+		return From{}
+	}
+}
+
+func convertEnvironmentCloningTo(in *EnvironmentCloning) *v1beta1.EnvironmentCloning {
+	if in == nil {
+		return nil
+	}
+	out := &v1beta1.EnvironmentCloning{Clone: in.Clone, NamePrefix: in.NamePrefix, NameSuffix: in.NameSuffix}
+	for _, mapping := range in.Mappings {
+		out.Mappings = append(out.Mappings, v1beta1.EnvironmentMapping(mapping))
+	}
+	return out
+}
+
+func convertEnvironmentCloningFrom(in *v1beta1.EnvironmentCloning) *EnvironmentCloning {
+	if in == nil {
+		return nil
+	}
+	out := &EnvironmentCloning{Clone: in.Clone, NamePrefix: in.NamePrefix, NameSuffix: in.NameSuffix}
+	for _, mapping := range in.Mappings {
+		out.Mappings = append(out.Mappings, EnvironmentMapping(mapping))
+	}
+	return out
+}
+
+func convertBuildSettingsTo(in *BuildSettings) *v1beta1.BuildSettings {
+	if in == nil {
+		return nil
+	}
+	in = in.DeepCopy()
+	return &v1beta1.BuildSettings{ImageVisibility: v1beta1.ImageVisibility(in.ImageVisibility), InitialBuild: in.InitialBuild, Annotations: in.Annotations}
+}
+
+func convertBuildSettingsFrom(in *v1beta1.BuildSettings) *BuildSettings {
+	if in == nil {
+		return nil
+	}
+	in = in.DeepCopy()
+	return &BuildSettings{ImageVisibility: ImageVisibility(in.ImageVisibility), InitialBuild: in.InitialBuild, Annotations: in.Annotations}
+}
+
+func convertIntegrationTestOverrideTo(in IntegrationTestOverride) v1beta1.IntegrationTestOverride {
+	out := v1beta1.IntegrationTestOverride{Name: in.Name, Environment: in.Environment}
+	for _, param := range in.ResolverParams {
+		out.ResolverParams = append(out.ResolverParams, v1beta1.ResolverParam(param))
+	}
+	for _, param := range in.Params {
+		out.Params = append(out.Params, *(*v1beta1.PipelineParam)(param.DeepCopy()))
+	}
+	return out
+}
+
+func convertIntegrationTestOverrideFrom(in v1beta1.IntegrationTestOverride) IntegrationTestOverride {
+	out := IntegrationTestOverride{Name: in.Name, Environment: in.Environment}
+	for _, param := range in.ResolverParams {
+		out.ResolverParams = append(out.ResolverParams, ResolverParam(param))
+	}
+	for _, param := range in.Params {
+		out.Params = append(out.Params, *(*PipelineParam)(param.DeepCopy()))
+	}
+	return out
+}
+
+// parseTime parses the RFC 3339 timestamps of the v1alpha1 status. Timestamps that can't be parsed are dropped.
+func parseTime(s string) *metav1.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
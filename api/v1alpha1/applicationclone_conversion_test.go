@@ -0,0 +1,337 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/redhat-appstudio/clone-controller/api/v1beta1"
+)
+
+func newTime(s string) *metav1.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	Expect(err).NotTo(HaveOccurred())
+	return &metav1.Time{Time: t}
+}
+
+func newHubApplicationClone() *v1beta1.ApplicationClone {
+	initialBuild := true
+	return &v1beta1.ApplicationClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar", Labels: map[string]string{"team": "billing"}},
+		Spec: v1beta1.ApplicationCloneSpec{
+			Source: v1beta1.ApplicationSource{
+				Application: &v1beta1.ApplicationReference{
+					Namespace: "foo",
+					Name:      "billing-app",
+					Cluster:   &v1beta1.ClusterRef{SecretName: "prod", Key: "config"},
+				},
+			},
+			Components: []v1beta1.ComponentCloning{
+				{Name: "c1", Mode: v1beta1.ComponentModeImage},
+				{Name: "c2", Mode: v1beta1.ComponentModeSource, Build: &v1beta1.BuildSettings{InitialBuild: &initialBuild}},
+				{Name: "c3", Mode: v1beta1.ComponentModeSnapshot},
+			},
+			DefaultMode: v1beta1.ComponentModeSkip,
+			Snapshot:    "billing-app-8xk2p",
+			Build: &v1beta1.BuildSettings{
+				ImageVisibility: v1beta1.ImageVisibilityPublic,
+				Annotations:     map[string]string{"build.appstudio.openshift.io/request": "configure-pac"},
+			},
+			IntegrationTests: &v1beta1.IntegrationTestSelection{
+				Include:  []string{"e2e-*"},
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "e2e"}},
+			},
+			IntegrationTestOverrides: []v1beta1.IntegrationTestOverride{{
+				Name:           "e2e-*",
+				ResolverParams: []v1beta1.ResolverParam{{Name: "revision", Value: "dev"}},
+				Params:         []v1beta1.PipelineParam{{Name: "regions", Values: []string{"eu", "us"}}},
+				Environment:    "ephemeral",
+			}},
+			ExtraResources: []v1beta1.ExtraResource{{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Selector:   metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/part-of": "billing-app"}},
+			}},
+			ConfigMapOverrides:    []v1beta1.ConfigMapOverride{{Name: "billing-config", Data: map[string]string{"LOG_LEVEL": "debug"}}},
+			Secrets:               []v1beta1.SecretCloning{{Name: "db-credentials", Strategy: v1beta1.SecretStrategyCopy}},
+			DefaultSecretStrategy: v1beta1.SecretStrategyPlaceholder,
+			ServiceAccountName:    "clone-bot",
+			Sanitization: []v1beta1.SanitizationRule{
+				{Value: `(\w+)\.prod\.example\.com`, Action: v1beta1.SanitizationActionReplace, Replacement: "${1}.staging.example.com"},
+				{Name: ".*_EMAIL", Action: v1beta1.SanitizationActionDrop},
+			},
+			Environments: &v1beta1.EnvironmentCloning{
+				Clone:      true,
+				NamePrefix: "dev-",
+				Mappings:   []v1beta1.EnvironmentMapping{{From: "production", To: "staging"}},
+			},
+			SnapshotEnvironmentBindings: &v1beta1.SnapshotEnvironmentBindingCloning{Clone: true},
+			GitOps:                      &v1beta1.GitOpsTarget{URL: "https://github.com/org/gitops.git", Branch: "main"},
+		},
+		Status: v1beta1.ApplicationCloneStatus{
+			Conditions: []metav1.Condition{{
+				Type:               v1beta1.ReadyCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             v1beta1.ClonedReason,
+				Message:            "The Application was cloned",
+				LastTransitionTime: *newTime("2023-06-01T10:00:00Z"),
+			}},
+			Resources:       []v1beta1.Resource{{Kind: "Application", Name: "billing-app"}},
+			Sanitized:       []v1beta1.SanitizedField{{Component: "c1", Env: "DB_HOST", Action: v1beta1.SanitizationActionReplace}},
+			LastAttemptTime: newTime("2023-06-02T10:00:00Z"),
+			LastSuccessTime: newTime("2023-06-02T10:00:00Z"),
+			Commit:          "2b1f7c3",
+		},
+	}
+}
+
+func newSpokeApplicationClone() *ApplicationClone {
+	return &ApplicationClone{
+		ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+		Spec: ApplicationCloneSpec{
+			From: From{
+				Name:   "billing-app",
+				Bundle: &BundleSource{OCI: &OCIArtifact{Reference: "quay.io/org/billing-app:v1", CredentialsSecret: "quay"}},
+			},
+			ComponentSources: []ComponentSource{{Name: "c2", Mode: ComponentModeSource}, {Name: "c1", Mode: ComponentModeSkip}},
+			DefaultMode:      ComponentModeImage,
+			IntegrationTests: &IntegrationTestSelection{None: true},
+		},
+		Status: ApplicationCloneStatus{
+			Error:                 "error reading bundle: 401 Unauthorized",
+			LastAttempt:           "2023-06-02T10:00:00Z",
+			LastSuccessfulAttempt: "2023-06-01T10:00:00Z",
+		},
+	}
+}
+
+var _ = Describe("ApplicationClone conversion", func() {
+
+	It("Should round trip v1beta1 through v1alpha1", func() {
+		for _, hub := range []*v1beta1.ApplicationClone{
+			newHubApplicationClone(),
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+				Spec: v1beta1.ApplicationCloneSpec{
+					Source: v1beta1.ApplicationSource{
+						Bundle: &v1beta1.BundleReference{Application: "billing-app", Namespace: "foo", ConfigMap: &v1beta1.ConfigMapBundleSource{Name: "billing-app"}},
+					},
+				},
+				Status: v1beta1.ApplicationCloneStatus{
+					Conditions: []metav1.Condition{{
+						Type:               v1beta1.ReadyCondition,
+						Status:             metav1.ConditionFalse,
+						Reason:             v1beta1.CloneFailedReason,
+						Message:            "error reading configmap",
+						LastTransitionTime: *newTime("2023-06-01T10:00:00Z"),
+					}},
+					LastAttemptTime: newTime("2023-06-02T10:00:00Z"),
+				},
+			},
+			{ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "bar"}},
+		} {
+			spoke := &ApplicationClone{}
+			Expect(spoke.ConvertFrom(hub)).To(Succeed())
+
+			after := &v1beta1.ApplicationClone{}
+			Expect(spoke.ConvertTo(after)).To(Succeed())
+			Expect(after).To(Equal(hub))
+		}
+	})
+
+	It("Should round trip v1alpha1 through v1beta1", func() {
+		for _, spoke := range []*ApplicationClone{
+			newSpokeApplicationClone(),
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar", Annotations: map[string]string{"note": "live"}},
+				Spec: ApplicationCloneSpec{
+					From:                     From{Namespace: "foo", Name: "billing-app", ClusterRef: &ClusterRef{SecretName: "prod"}},
+					IntegrationTestOverrides: []IntegrationTestOverride{{Name: "*", Params: []PipelineParam{{Name: "debug", Value: "true"}}}},
+					Environments:             &EnvironmentCloning{Mappings: []EnvironmentMapping{{From: "production", To: "staging"}}},
+				},
+				Status: ApplicationCloneStatus{
+					Resources:             []Resource{{Kind: "Component", Name: "c1"}},
+					LastAttempt:           "2023-06-02T10:00:00Z",
+					LastSuccessfulAttempt: "2023-06-02T10:00:00Z",
+					Commit:                "2b1f7c3",
+				},
+			},
+			{ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "bar"}},
+		} {
+			hub := &v1beta1.ApplicationClone{}
+			Expect(spoke.ConvertTo(hub)).To(Succeed())
+
+			after := &ApplicationClone{}
+			Expect(after.ConvertFrom(hub)).To(Succeed())
+			// the annotation keeps the v1beta1 conditions
+			delete(after.Annotations, ConversionAnnotation)
+			if len(after.Annotations) == 0 {
+				after.Annotations = nil
+			}
+			Expect(after).To(Equal(spoke))
+		}
+	})
+
+	It("Should convert v1alpha1 to v1beta1", func() {
+		spoke := newSpokeApplicationClone()
+		// the mode of a Component defaults to Source
+		spoke.Spec.ComponentSources = append(spoke.Spec.ComponentSources, ComponentSource{Name: "c3"})
+
+		hub := &v1beta1.ApplicationClone{}
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+
+		Expect(hub.Spec.Source).To(Equal(v1beta1.ApplicationSource{
+			Bundle: &v1beta1.BundleReference{
+				Application: "billing-app",
+				OCI:         &v1beta1.OCIArtifact{Reference: "quay.io/org/billing-app:v1", CredentialsSecret: "quay"},
+			},
+		}))
+		Expect(hub.Spec.Components).To(Equal([]v1beta1.ComponentCloning{
+			{Name: "c2", Mode: v1beta1.ComponentModeSource},
+			{Name: "c1", Mode: v1beta1.ComponentModeSkip},
+			{Name: "c3", Mode: v1beta1.ComponentModeSource},
+		}))
+		Expect(hub.Spec.DefaultMode).To(Equal(v1beta1.ComponentModeImage))
+		Expect(hub.Status.Conditions).To(HaveLen(1))
+		Expect(hub.Status.Conditions[0].Status).To(Equal(metav1.ConditionFalse))
+		Expect(hub.Status.Conditions[0].Reason).To(Equal(v1beta1.CloneFailedReason))
+		Expect(hub.Status.Conditions[0].Message).To(Equal("error reading bundle: 401 Unauthorized"))
+		Expect(hub.Status.Conditions[0].LastTransitionTime).To(Equal(*newTime("2023-06-02T10:00:00Z")))
+		Expect(hub.Status.LastSuccessTime).To(Equal(newTime("2023-06-01T10:00:00Z")))
+
+		// a ClusterRef can't be combined with a bundle
+		spoke = newSpokeApplicationClone()
+		spoke.Spec.From.ClusterRef = &ClusterRef{SecretName: "prod"}
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.Source.Application).To(BeNil())
+	})
+
+	It("Should apply changes made through v1alpha1", func() {
+		spoke := &ApplicationClone{}
+		Expect(spoke.ConvertFrom(newHubApplicationClone())).To(Succeed())
+		initialBuild := true
+		Expect(spoke.Spec.ComponentSources).To(Equal([]ComponentSource{
+			{Name: "c1", Mode: ComponentModeImage},
+			{Name: "c2", Mode: ComponentModeSource, Build: &BuildSettings{InitialBuild: &initialBuild}},
+			{Name: "c3", Mode: ComponentModeSnapshot},
+		}))
+		Expect(spoke.Spec.Snapshot).To(Equal("billing-app-8xk2p"))
+		Expect(spoke.Spec.Build.ImageVisibility).To(Equal(ImageVisibilityPublic))
+		Expect(spoke.Spec.From).To(Equal(From{Namespace: "foo", Name: "billing-app", ClusterRef: &ClusterRef{SecretName: "prod", Key: "config"}}))
+		Expect(spoke.Status.LastSuccessfulAttempt).To(Equal("2023-06-02T10:00:00Z"))
+		Expect(spoke.Status.Error).To(BeEmpty())
+
+		// the controller fails a new attempt
+		spoke.Status.LastAttempt = "2023-06-03T10:00:00Z"
+		spoke.Status.Error = "error cloning repository"
+
+		hub := &v1beta1.ApplicationClone{}
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Annotations).NotTo(HaveKey(ConversionAnnotation))
+		Expect(hub.Status.Conditions).To(HaveLen(1))
+		Expect(hub.Status.Conditions[0].Status).To(Equal(metav1.ConditionFalse))
+		Expect(hub.Status.Conditions[0].LastTransitionTime).To(Equal(*newTime("2023-06-03T10:00:00Z")))
+
+		// the next failure keeps the transition time
+		Expect(spoke.ConvertFrom(hub)).To(Succeed())
+		spoke.Status.LastAttempt = "2023-06-04T10:00:00Z"
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Status.Conditions[0].LastTransitionTime).To(Equal(*newTime("2023-06-03T10:00:00Z")))
+	})
+
+	It("Should not be Ready while placeholder Secrets are pending", func() {
+		spoke := &ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+			Status: ApplicationCloneStatus{
+				LastAttempt:           "2023-06-02T10:00:00Z",
+				LastSuccessfulAttempt: "2023-06-02T10:00:00Z",
+				PendingSecrets:        []string{"db-credentials", "git-token"},
+			},
+		}
+		hub := &v1beta1.ApplicationClone{}
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Status.PendingSecrets).To(Equal([]string{"db-credentials", "git-token"}))
+		Expect(hub.Status.Conditions).To(HaveLen(1))
+		Expect(hub.Status.Conditions[0].Status).To(Equal(metav1.ConditionFalse))
+		Expect(hub.Status.Conditions[0].Reason).To(Equal(v1beta1.SecretsPendingReason))
+		Expect(hub.Status.Conditions[0].Message).To(ContainSubstring("db-credentials, git-token"))
+
+		// pending Secrets aren't an error
+		Expect(spoke.ConvertFrom(hub)).To(Succeed())
+		Expect(spoke.Status.Error).To(BeEmpty())
+
+		// once they are filled in
+		spoke.Status.PendingSecrets = nil
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Status.Conditions[0].Status).To(Equal(metav1.ConditionTrue))
+		Expect(hub.Status.Conditions[0].Reason).To(Equal(v1beta1.ClonedReason))
+	})
+
+	It("Should report policy violations in the Ready condition", func() {
+		spoke := &ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+			Status: ApplicationCloneStatus{
+				LastAttempt:      "2023-06-02T10:00:00Z",
+				Error:            "the ApplicationClone violates the ClonePolicies of the cluster: no-secrets: Secret git-token can't be cloned in the Copy strategy",
+				PolicyViolations: []PolicyViolation{{Policy: "no-secrets", Message: "Secret git-token can't be cloned in the Copy strategy"}},
+			},
+		}
+		hub := &v1beta1.ApplicationClone{}
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Status.PolicyViolations).To(Equal([]v1beta1.PolicyViolation{{Policy: "no-secrets", Message: "Secret git-token can't be cloned in the Copy strategy"}}))
+		Expect(hub.Status.Conditions).To(HaveLen(1))
+		Expect(hub.Status.Conditions[0].Status).To(Equal(metav1.ConditionFalse))
+		Expect(hub.Status.Conditions[0].Reason).To(Equal(v1beta1.PolicyViolationReason))
+
+		Expect(spoke.ConvertFrom(hub)).To(Succeed())
+		Expect(spoke.Status.Error).To(ContainSubstring("no-secrets"))
+		Expect(spoke.Status.PolicyViolations).To(HaveLen(1))
+	})
+
+	It("Should not be Ready while waiting for an approval", func() {
+		spoke := &ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+			Status: ApplicationCloneStatus{
+				LastAttempt:     "2023-06-02T10:00:00Z",
+				PendingApproval: true,
+			},
+		}
+		hub := &v1beta1.ApplicationClone{}
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Status.Conditions).To(HaveLen(1))
+		Expect(hub.Status.Conditions[0].Status).To(Equal(metav1.ConditionFalse))
+		Expect(hub.Status.Conditions[0].Reason).To(Equal(v1beta1.PendingApprovalReason))
+
+		// waiting for an approval isn't an error
+		Expect(spoke.ConvertFrom(hub)).To(Succeed())
+		Expect(spoke.Status.Error).To(BeEmpty())
+		Expect(spoke.Status.PendingApproval).To(BeTrue())
+
+		// once approved
+		spoke.Status.PendingApproval = false
+		spoke.Status.LastSuccessfulAttempt = "2023-06-02T11:00:00Z"
+		spoke.Status.Approval = &ApprovalRecord{Name: "billing-to-dev", Approver: "alice", ExpirationTime: *newTime("2023-06-03T10:00:00Z")}
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Status.Conditions[0].Status).To(Equal(metav1.ConditionTrue))
+		Expect(hub.Status.Approval).To(Equal(&v1beta1.ApprovalRecord{Name: "billing-to-dev", Approver: "alice", ExpirationTime: *newTime("2023-06-03T10:00:00Z")}))
+	})
+})
@@ -0,0 +1,101 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ApplicationCloneApprovalSpec approves an ApplicationClone to clone, or an ApplicationExport to export, a protected
+// Application of the namespace of the approval
+type ApplicationCloneApprovalSpec struct {
+	// Application is the name of the Application that may be cloned
+	// +kubebuilder:validation:MinLength=1
+	Application string `json:"application"`
+
+	// Clone is the ApplicationClone that may clone it, or the ApplicationExport that may export it
+	Clone CloneReference `json:"clone"`
+
+	// ExpirationTime is when the approval expires. The ApplicationClone can't clone the Application under the
+	// approval afterwards.
+	ExpirationTime metav1.Time `json:"expirationTime"`
+
+	// Approver is the user who created or last updated the approval. It is recorded by the webhook of the
+	// controller, which replaces any value given and only admits users allowed to update the Application.
+	Approver string `json:"approver,omitempty"`
+}
+
+// CloneReference references an ApplicationClone or an ApplicationExport
+type CloneReference struct {
+	// Kind of the referenced resource
+	// +kubebuilder:validation:Enum=ApplicationClone;ApplicationExport
+	// +kubebuilder:default=ApplicationClone
+	Kind string `json:"kind,omitempty"`
+
+	// Namespace of the ApplicationClone or ApplicationExport
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Name of the ApplicationClone or ApplicationExport
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Generation of the ApplicationClone or ApplicationExport that is approved. Once its spec changes, the
+	// approval no longer applies. The webhook of the controller records the current generation when it is unset.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// UID of the ApplicationClone or ApplicationExport that is approved, so that the approval doesn't carry over to
+	// a resource recreated with the same name. The webhook of the controller records the current UID when it is
+	// unset.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Application",type=string,JSONPath=`.spec.application`
+//+kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.clone.kind`
+//+kubebuilder:printcolumn:name="Clone Namespace",type=string,JSONPath=`.spec.clone.namespace`
+//+kubebuilder:printcolumn:name="Clone",type=string,JSONPath=`.spec.clone.name`
+//+kubebuilder:printcolumn:name="Generation",type=integer,JSONPath=`.spec.clone.generation`
+//+kubebuilder:printcolumn:name="Approver",type=string,JSONPath=`.spec.approver`
+//+kubebuilder:printcolumn:name="Expires",type=string,format=date-time,JSONPath=`.spec.expirationTime`
+
+// ApplicationCloneApproval lets an ApplicationClone clone, or an ApplicationExport export, a protected Application of
+// its namespace until it expires.
+// Creating and updating approvals is restricted to the users allowed to by the RBAC of the namespace, and who may
+// update the approved Application.
+type ApplicationCloneApproval struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ApplicationCloneApprovalSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ApplicationCloneApprovalList contains a list of ApplicationCloneApproval
+type ApplicationCloneApprovalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApplicationCloneApproval `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApplicationCloneApproval{}, &ApplicationCloneApprovalList{})
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/mutate-appstudio-redhat-com-v1alpha1-applicationcloneapproval,mutating=true,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=applicationcloneapprovals,verbs=create;update,versions=v1alpha1,name=mapplicationcloneapproval.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1alpha1-applicationcloneapproval,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=applicationcloneapprovals,verbs=create;update,versions=v1alpha1,name=vapplicationcloneapproval.kb.io,admissionReviewVersions=v1
+
+// SetupWebhookWithManager registers the webhook recording the approvers of ApplicationCloneApprovals, and the
+// generation and UID of what they approve, with the manager. The validator, if any, decides who may approve.
+func (r *ApplicationCloneApproval) SetupWebhookWithManager(mgr ctrl.Manager, validator admission.CustomValidator) error {
+	builder := ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithDefaulter(approvalRecorder{client: mgr.GetClient()})
+	if validator != nil {
+		builder = builder.WithValidator(validator)
+	}
+	return builder.Complete()
+}
+
+// approvalRecorder records the user creating or updating an ApplicationCloneApproval as its approver, and the
+// current generation and UID of the ApplicationClone or ApplicationExport it approves unless they are given
+type approvalRecorder struct {
+	client client.Reader
+}
+
+var _ admission.CustomDefaulter = approvalRecorder{}
+
+// Default sets the approver to the user of the admission request, and the generation and UID of the approved
+// resource
+func (d approvalRecorder) Default(ctx context.Context, obj runtime.Object) error {
+	approval, ok := obj.(*ApplicationCloneApproval)
+	if !ok {
+		return fmt.Errorf("expected an ApplicationCloneApproval, got %T", obj)
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	approval.Spec.Approver = req.UserInfo.Username
+
+	if approval.Spec.Clone.Generation != 0 && approval.Spec.Clone.UID != "" {
+		return nil
+	}
+	var approved client.Object = &ApplicationClone{}
+	if approval.Spec.Clone.Kind == "ApplicationExport" {
+		approved = &ApplicationExport{}
+	}
+	err = d.client.Get(ctx, types.NamespacedName{Namespace: approval.Spec.Clone.Namespace, Name: approval.Spec.Clone.Name}, approved)
+	// the resource may not exist yet, in which case its generation and UID must be given
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s %s: %w", approval.Spec.Clone.Kind, approval.Spec.Clone.Name, err)
+	}
+	if approval.Spec.Clone.Generation == 0 {
+		approval.Spec.Clone.Generation = approved.GetGeneration()
+	}
+	if approval.Spec.Clone.UID == "" {
+		approval.Spec.Clone.UID = approved.GetUID()
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("ApplicationCloneApproval webhook", func() {
+
+	var recorder approvalRecorder
+	var ctx context.Context
+
+	BeforeEach(func() {
+		testScheme := runtime.NewScheme()
+		Expect(AddToScheme(testScheme)).To(Succeed())
+		recorder = approvalRecorder{client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			&ApplicationClone{ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar", Generation: 3, UID: "clone-uid"}},
+			&ApplicationExport{ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar", Generation: 5, UID: "export-uid"}},
+		).Build()}
+		ctx = admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: "alice"},
+		}})
+	})
+
+	It("Should record the user of the request as the approver", func() {
+		approval := &ApplicationCloneApproval{Spec: ApplicationCloneApprovalSpec{Application: "billing-app", Approver: "someone-else"}}
+		Expect(recorder.Default(ctx, approval)).To(Succeed())
+		Expect(approval.Spec.Approver).To(Equal("alice"))
+	})
+
+	It("Should record the current generation and UID of the approved resource unless given", func() {
+		approval := &ApplicationCloneApproval{Spec: ApplicationCloneApprovalSpec{Application: "billing-app", Clone: CloneReference{Kind: "ApplicationClone", Namespace: "bar", Name: "billing"}}}
+		Expect(recorder.Default(ctx, approval)).To(Succeed())
+		Expect(approval.Spec.Clone.Generation).To(BeEquivalentTo(3))
+		Expect(approval.Spec.Clone.UID).To(BeEquivalentTo("clone-uid"))
+
+		approval.Spec.Clone = CloneReference{Kind: "ApplicationExport", Namespace: "bar", Name: "billing"}
+		Expect(recorder.Default(ctx, approval)).To(Succeed())
+		Expect(approval.Spec.Clone.Generation).To(BeEquivalentTo(5))
+		Expect(approval.Spec.Clone.UID).To(BeEquivalentTo("export-uid"))
+
+		approval.Spec.Clone.Generation = 2
+		approval.Spec.Clone.UID = "other-uid"
+		Expect(recorder.Default(ctx, approval)).To(Succeed())
+		Expect(approval.Spec.Clone.Generation).To(BeEquivalentTo(2))
+		Expect(approval.Spec.Clone.UID).To(BeEquivalentTo("other-uid"))
+
+		// e.g. not created yet
+		approval.Spec.Clone = CloneReference{Kind: "ApplicationClone", Namespace: "bar", Name: "missing"}
+		Expect(recorder.Default(ctx, approval)).To(Succeed())
+		Expect(approval.Spec.Clone.Generation).To(BeZero())
+		Expect(approval.Spec.Clone.UID).To(BeEmpty())
+	})
+})
@@ -0,0 +1,130 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationExportSpec defines the desired state of ApplicationExport
+type ApplicationExportSpec struct {
+	// From specifies the Application that is exported
+	From From `json:"from"`
+
+	// IntegrationTests selects which IntegrationTestScenarios of the Application are exported.
+	// All of them are exported when unset.
+	IntegrationTests *IntegrationTestSelection `json:"integrationTests,omitempty"`
+
+	// Secrets controls how the Secrets referenced by the Components are exported
+	// +kubebuilder:default=Redacted
+	Secrets SecretExportPolicy `json:"secrets,omitempty"`
+
+	// To specifies where the bundle is stored
+	To ExportTarget `json:"to"`
+}
+
+// SecretExportPolicy describes how Secrets are written to a bundle
+// +kubebuilder:validation:Enum=None;Redacted;Include
+type SecretExportPolicy string
+
+const (
+	// SecretExportNone leaves the Secrets out of the bundle
+	SecretExportNone SecretExportPolicy = "None"
+
+	// SecretExportRedacted exports the Secrets' keys with empty values
+	SecretExportRedacted SecretExportPolicy = "Redacted"
+
+	// SecretExportInclude exports the Secrets with their values
+	SecretExportInclude SecretExportPolicy = "Include"
+)
+
+// ExportTarget is where a bundle is written to. Exactly one of the fields must be set.
+type ExportTarget struct {
+	// ConfigMap is the name of a ConfigMap in the current namespace the bundle is written to.
+	// It is created if it doesn't exist; an existing ConfigMap is only overwritten if it holds a bundle of this
+	// ApplicationExport, as recorded by its clone.appstudio.redhat.com/export annotation. Secret values can't be
+	// included.
+	ConfigMap string `json:"configMap,omitempty"`
+
+	// PersistentVolumeClaim is a claim in the current namespace the bundle is copied to
+	PersistentVolumeClaim *PersistentVolumeClaimTarget `json:"persistentVolumeClaim,omitempty"`
+
+	// OCI is the tag the bundle is pushed to as an OCI artifact
+	OCI *OCIArtifact `json:"oci,omitempty"`
+}
+
+type PersistentVolumeClaimTarget struct {
+	// ClaimName is the name of the PersistentVolumeClaim
+	ClaimName string `json:"claimName"`
+
+	// Path of the bundle file in the volume. Defaults to "<ApplicationExport name>.yaml".
+	Path string `json:"path,omitempty"`
+}
+
+// ApplicationExportStatus defines the observed state of ApplicationExport
+type ApplicationExportStatus struct {
+	// List of Resources that were exported
+	Resources []Resource `json:"resources,omitempty"`
+
+	// BundleVersion is the version of the bundle format that was written
+	BundleVersion string `json:"bundleVersion,omitempty"`
+
+	// ConfigMap is the name of the ConfigMap holding the bundle
+	ConfigMap string `json:"configMap,omitempty"`
+
+	// Digest of the OCI artifact the bundle was pushed as
+	Digest string `json:"digest,omitempty"`
+
+	// PolicyViolations lists the rules of the ClonePolicies of the cluster that prevented the last attempt
+	PolicyViolations []PolicyViolation `json:"policyViolations,omitempty"`
+
+	// PendingApproval tells that the Application is protected and no ApplicationCloneApproval of its namespace
+	// currently approves the export
+	PendingApproval bool `json:"pendingApproval,omitempty"`
+
+	// Approval is the ApplicationCloneApproval the last attempt to export a protected Application was approved by
+	Approval *ApprovalRecord `json:"approval,omitempty"`
+
+	Error                 string `json:"error,omitempty"`
+	LastSuccessfulAttempt string `json:"lastSuccessfulAttempt,omitempty"`
+	LastAttempt           string `json:"lastAttempt,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ApplicationExport is the Schema for the applicationexports API
+type ApplicationExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationExportSpec   `json:"spec,omitempty"`
+	Status ApplicationExportStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ApplicationExportList contains a list of ApplicationExport
+type ApplicationExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApplicationExport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApplicationExport{}, &ApplicationExportList{})
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CloneGrantSpec defines who may clone the Applications of the namespace of the CloneGrant, and what
+type CloneGrantSpec struct {
+	// From lists the ApplicationClones the grant is given to. An ApplicationClone matching any of them is granted.
+	// +kubebuilder:validation:MinItems=1
+	From []CloneGrantFrom `json:"from"`
+
+	// Applications lists the names of the Applications that may be cloned, as glob patterns, e.g. "billing-*".
+	// All the Applications of the namespace may be cloned when empty.
+	Applications []string `json:"applications,omitempty"`
+
+	// Kinds lists the kinds of the resources that may be cloned besides Applications and Components. All the
+	// kinds but Secrets may be cloned when empty. Secrets copied with their values must always be listed;
+	// placeholder Secrets don't hold any value and need no grant.
+	Kinds []GrantedKind `json:"kinds,omitempty"`
+}
+
+// CloneGrantFrom selects ApplicationClones and ApplicationExports by the namespace they clone or export into, and
+// optionally the ServiceAccount they clone on behalf of
+type CloneGrantFrom struct {
+	// Namespace of the ApplicationClones and ApplicationExports
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// ServiceAccount restricts the grant to the ApplicationClones whose serviceAccountName is the named
+	// ServiceAccount of the namespace. ApplicationExports aren't granted then.
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+// GrantedKind is a kind of resources that may be cloned
+type GrantedKind struct {
+	// Group of the kind, empty for the core API group
+	Group string `json:"group,omitempty"`
+
+	// Kind, e.g. "Secret"
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+}
+
+//+kubebuilder:object:root=true
+
+// CloneGrant lets ApplicationClones of other namespaces clone the Applications of its namespace. Once the
+// controller requires grants, an Application can only be cloned into another namespace if a CloneGrant of its
+// namespace allows it.
+type CloneGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CloneGrantSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CloneGrantList contains a list of CloneGrant
+type CloneGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloneGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CloneGrant{}, &CloneGrantList{})
+}
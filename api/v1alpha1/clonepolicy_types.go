@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClonePolicySpec defines the rules every ApplicationClone and ApplicationExport of the cluster must follow. Unset rules
+// don't restrict anything.
+type ClonePolicySpec struct {
+	// BlockedSourceNamespaces lists the namespaces Applications may not be cloned or exported from, as glob patterns,
+	// e.g. "prod-*". Bundles exported from these namespaces may not be cloned either.
+	BlockedSourceNamespaces []string `json:"blockedSourceNamespaces,omitempty"`
+
+	// AllowedSecretStrategies lists the strategies Secrets may be cloned in. Leaving Copy out forbids copying the
+	// values of Secrets, and exporting them.
+	AllowedSecretStrategies []SecretStrategy `json:"allowedSecretStrategies,omitempty"`
+
+	// MaxComponents caps the number of Components of a clone
+	// +kubebuilder:validation:Minimum=0
+	MaxComponents *int32 `json:"maxComponents,omitempty"`
+
+	// RequireImageDigests requires the images of the Components cloned from an image, and the OCI bundles
+	// Applications are cloned from, to be pinned by digest
+	RequireImageDigests bool `json:"requireImageDigests,omitempty"`
+
+	// ImageVisibility forces the visibility of the images built for the Components cloned from source
+	ImageVisibility ImageVisibility `json:"imageVisibility,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+
+// ClonePolicy holds guardrails set by the administrators of the cluster, which ApplicationClones and
+// ApplicationExports can't override. Every ClonePolicy applies to every ApplicationClone and ApplicationExport.
+type ClonePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClonePolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClonePolicyList contains a list of ClonePolicy
+type ClonePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClonePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClonePolicy{}, &ClonePolicyList{})
+}
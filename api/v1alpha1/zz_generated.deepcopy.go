@@ -22,7 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -52,6 +53,81 @@ func (in *ApplicationClone) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationCloneApproval) DeepCopyInto(out *ApplicationCloneApproval) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationCloneApproval.
+func (in *ApplicationCloneApproval) DeepCopy() *ApplicationCloneApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationCloneApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationCloneApproval) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationCloneApprovalList) DeepCopyInto(out *ApplicationCloneApprovalList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApplicationCloneApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationCloneApprovalList.
+func (in *ApplicationCloneApprovalList) DeepCopy() *ApplicationCloneApprovalList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationCloneApprovalList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationCloneApprovalList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationCloneApprovalSpec) DeepCopyInto(out *ApplicationCloneApprovalSpec) {
+	*out = *in
+	out.Clone = in.Clone
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationCloneApprovalSpec.
+func (in *ApplicationCloneApprovalSpec) DeepCopy() *ApplicationCloneApprovalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationCloneApprovalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationCloneList) DeepCopyInto(out *ApplicationCloneList) {
 	*out = *in
@@ -87,12 +163,70 @@ func (in *ApplicationCloneList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationCloneSpec) DeepCopyInto(out *ApplicationCloneSpec) {
 	*out = *in
-	out.From = in.From
+	in.From.DeepCopyInto(&out.From)
 	if in.ComponentSources != nil {
 		in, out := &in.ComponentSources, &out.ComponentSources
 		*out = make([]ComponentSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Build != nil {
+		in, out := &in.Build, &out.Build
+		*out = new(BuildSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrationTests != nil {
+		in, out := &in.IntegrationTests, &out.IntegrationTests
+		*out = new(IntegrationTestSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrationTestOverrides != nil {
+		in, out := &in.IntegrationTestOverrides, &out.IntegrationTestOverrides
+		*out = make([]IntegrationTestOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = new(EnvironmentCloning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotEnvironmentBindings != nil {
+		in, out := &in.SnapshotEnvironmentBindings, &out.SnapshotEnvironmentBindings
+		*out = new(SnapshotEnvironmentBindingCloning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraResources != nil {
+		in, out := &in.ExtraResources, &out.ExtraResources
+		*out = make([]ExtraResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigMapOverrides != nil {
+		in, out := &in.ConfigMapOverrides, &out.ConfigMapOverrides
+		*out = make([]ConfigMapOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretCloning, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sanitization != nil {
+		in, out := &in.Sanitization, &out.Sanitization
+		*out = make([]SanitizationRule, len(*in))
 		copy(*out, *in)
 	}
+	if in.GitOps != nil {
+		in, out := &in.GitOps, &out.GitOps
+		*out = new(GitOpsTarget)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationCloneSpec.
@@ -113,6 +247,26 @@ func (in *ApplicationCloneStatus) DeepCopyInto(out *ApplicationCloneStatus) {
 		*out = make([]Resource, len(*in))
 		copy(*out, *in)
 	}
+	if in.PendingSecrets != nil {
+		in, out := &in.PendingSecrets, &out.PendingSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sanitized != nil {
+		in, out := &in.Sanitized, &out.Sanitized
+		*out = make([]SanitizedField, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyViolations != nil {
+		in, out := &in.PolicyViolations, &out.PolicyViolations
+		*out = make([]PolicyViolation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalRecord)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationCloneStatus.
@@ -126,46 +280,811 @@ func (in *ApplicationCloneStatus) DeepCopy() *ApplicationCloneStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentSource) DeepCopyInto(out *ComponentSource) {
+func (in *ApplicationExport) DeepCopyInto(out *ApplicationExport) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSource.
-func (in *ComponentSource) DeepCopy() *ComponentSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationExport.
+func (in *ApplicationExport) DeepCopy() *ApplicationExport {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentSource)
+	out := new(ApplicationExport)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *From) DeepCopyInto(out *From) {
+func (in *ApplicationExportList) DeepCopyInto(out *ApplicationExportList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApplicationExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new From.
-func (in *From) DeepCopy() *From {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationExportList.
+func (in *ApplicationExportList) DeepCopy() *ApplicationExportList {
 	if in == nil {
 		return nil
 	}
-	out := new(From)
+	out := new(ApplicationExportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Resource) DeepCopyInto(out *Resource) {
+func (in *ApplicationExportSpec) DeepCopyInto(out *ApplicationExportSpec) {
 	*out = *in
+	in.From.DeepCopyInto(&out.From)
+	if in.IntegrationTests != nil {
+		in, out := &in.IntegrationTests, &out.IntegrationTests
+		*out = new(IntegrationTestSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	in.To.DeepCopyInto(&out.To)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resource.
-func (in *Resource) DeepCopy() *Resource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationExportSpec.
+func (in *ApplicationExportSpec) DeepCopy() *ApplicationExportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Resource)
+	out := new(ApplicationExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationExportStatus) DeepCopyInto(out *ApplicationExportStatus) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]Resource, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyViolations != nil {
+		in, out := &in.PolicyViolations, &out.PolicyViolations
+		*out = make([]PolicyViolation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalRecord)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationExportStatus.
+func (in *ApplicationExportStatus) DeepCopy() *ApplicationExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalRecord) DeepCopyInto(out *ApprovalRecord) {
+	*out = *in
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalRecord.
+func (in *ApprovalRecord) DeepCopy() *ApprovalRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildSettings) DeepCopyInto(out *BuildSettings) {
+	*out = *in
+	if in.InitialBuild != nil {
+		in, out := &in.InitialBuild, &out.InitialBuild
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildSettings.
+func (in *BuildSettings) DeepCopy() *BuildSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleSource) DeepCopyInto(out *BundleSource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapBundleSource)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIArtifact)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleSource.
+func (in *BundleSource) DeepCopy() *BundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneGrant) DeepCopyInto(out *CloneGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrant.
+func (in *CloneGrant) DeepCopy() *CloneGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloneGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneGrantFrom) DeepCopyInto(out *CloneGrantFrom) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantFrom.
+func (in *CloneGrantFrom) DeepCopy() *CloneGrantFrom {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrantFrom)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneGrantList) DeepCopyInto(out *CloneGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CloneGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantList.
+func (in *CloneGrantList) DeepCopy() *CloneGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CloneGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneGrantSpec) DeepCopyInto(out *CloneGrantSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]CloneGrantFrom, len(*in))
+		copy(*out, *in)
+	}
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]GrantedKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneGrantSpec.
+func (in *CloneGrantSpec) DeepCopy() *CloneGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClonePolicy) DeepCopyInto(out *ClonePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClonePolicy.
+func (in *ClonePolicy) DeepCopy() *ClonePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClonePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClonePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClonePolicyList) DeepCopyInto(out *ClonePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClonePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClonePolicyList.
+func (in *ClonePolicyList) DeepCopy() *ClonePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClonePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClonePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClonePolicySpec) DeepCopyInto(out *ClonePolicySpec) {
+	*out = *in
+	if in.BlockedSourceNamespaces != nil {
+		in, out := &in.BlockedSourceNamespaces, &out.BlockedSourceNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedSecretStrategies != nil {
+		in, out := &in.AllowedSecretStrategies, &out.AllowedSecretStrategies
+		*out = make([]SecretStrategy, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxComponents != nil {
+		in, out := &in.MaxComponents, &out.MaxComponents
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClonePolicySpec.
+func (in *ClonePolicySpec) DeepCopy() *ClonePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClonePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloneReference) DeepCopyInto(out *CloneReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloneReference.
+func (in *CloneReference) DeepCopy() *CloneReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CloneReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRef) DeepCopyInto(out *ClusterRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRef.
+func (in *ClusterRef) DeepCopy() *ClusterRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSource) DeepCopyInto(out *ComponentSource) {
+	*out = *in
+	if in.Build != nil {
+		in, out := &in.Build, &out.Build
+		*out = new(BuildSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSource.
+func (in *ComponentSource) DeepCopy() *ComponentSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapBundleSource) DeepCopyInto(out *ConfigMapBundleSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapBundleSource.
+func (in *ConfigMapBundleSource) DeepCopy() *ConfigMapBundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapBundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapOverride) DeepCopyInto(out *ConfigMapOverride) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapOverride.
+func (in *ConfigMapOverride) DeepCopy() *ConfigMapOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentCloning) DeepCopyInto(out *EnvironmentCloning) {
+	*out = *in
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]EnvironmentMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentCloning.
+func (in *EnvironmentCloning) DeepCopy() *EnvironmentCloning {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentCloning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentMapping) DeepCopyInto(out *EnvironmentMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentMapping.
+func (in *EnvironmentMapping) DeepCopy() *EnvironmentMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExportTarget) DeepCopyInto(out *ExportTarget) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(PersistentVolumeClaimTarget)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIArtifact)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExportTarget.
+func (in *ExportTarget) DeepCopy() *ExportTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ExportTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraResource) DeepCopyInto(out *ExtraResource) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraResource.
+func (in *ExtraResource) DeepCopy() *ExtraResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *From) DeepCopyInto(out *From) {
+	*out = *in
+	if in.ClusterRef != nil {
+		in, out := &in.ClusterRef, &out.ClusterRef
+		*out = new(ClusterRef)
+		**out = **in
+	}
+	if in.Bundle != nil {
+		in, out := &in.Bundle, &out.Bundle
+		*out = new(BundleSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new From.
+func (in *From) DeepCopy() *From {
+	if in == nil {
+		return nil
+	}
+	out := new(From)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsTarget) DeepCopyInto(out *GitOpsTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsTarget.
+func (in *GitOpsTarget) DeepCopy() *GitOpsTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantedKind) DeepCopyInto(out *GrantedKind) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantedKind.
+func (in *GrantedKind) DeepCopy() *GrantedKind {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantedKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTestOverride) DeepCopyInto(out *IntegrationTestOverride) {
+	*out = *in
+	if in.ResolverParams != nil {
+		in, out := &in.ResolverParams, &out.ResolverParams
+		*out = make([]ResolverParam, len(*in))
+		copy(*out, *in)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]PipelineParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTestOverride.
+func (in *IntegrationTestOverride) DeepCopy() *IntegrationTestOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTestOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTestSelection) DeepCopyInto(out *IntegrationTestSelection) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Contexts != nil {
+		in, out := &in.Contexts, &out.Contexts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTestSelection.
+func (in *IntegrationTestSelection) DeepCopy() *IntegrationTestSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTestSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIArtifact) DeepCopyInto(out *OCIArtifact) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIArtifact.
+func (in *OCIArtifact) DeepCopy() *OCIArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistentVolumeClaimTarget) DeepCopyInto(out *PersistentVolumeClaimTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimTarget.
+func (in *PersistentVolumeClaimTarget) DeepCopy() *PersistentVolumeClaimTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineParam) DeepCopyInto(out *PipelineParam) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineParam.
+func (in *PipelineParam) DeepCopy() *PipelineParam {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyViolation) DeepCopyInto(out *PolicyViolation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyViolation.
+func (in *PolicyViolation) DeepCopy() *PolicyViolation {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyViolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolverParam) DeepCopyInto(out *ResolverParam) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverParam.
+func (in *ResolverParam) DeepCopy() *ResolverParam {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolverParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Resource) DeepCopyInto(out *Resource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resource.
+func (in *Resource) DeepCopy() *Resource {
+	if in == nil {
+		return nil
+	}
+	out := new(Resource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SanitizationRule) DeepCopyInto(out *SanitizationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SanitizationRule.
+func (in *SanitizationRule) DeepCopy() *SanitizationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SanitizationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SanitizedField) DeepCopyInto(out *SanitizedField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SanitizedField.
+func (in *SanitizedField) DeepCopy() *SanitizedField {
+	if in == nil {
+		return nil
+	}
+	out := new(SanitizedField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretCloning) DeepCopyInto(out *SecretCloning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretCloning.
+func (in *SecretCloning) DeepCopy() *SecretCloning {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretCloning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotEnvironmentBindingCloning) DeepCopyInto(out *SnapshotEnvironmentBindingCloning) {
+	*out = *in
+	if in.RewriteEnv != nil {
+		in, out := &in.RewriteEnv, &out.RewriteEnv
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotEnvironmentBindingCloning.
+func (in *SnapshotEnvironmentBindingCloning) DeepCopy() *SnapshotEnvironmentBindingCloning {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotEnvironmentBindingCloning)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -0,0 +1,540 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationCloneSpec defines the desired state of ApplicationClone
+type ApplicationCloneSpec struct {
+	// Source is the Application that is cloned into the namespace of the ApplicationClone
+	Source ApplicationSource `json:"source"`
+
+	// ServiceAccountName is the ServiceAccount of the namespace the Application is cloned on behalf of, which
+	// CloneGrants of the source namespace may be restricted to. Only that ServiceAccount, and the users allowed to
+	// impersonate it, may set it.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// Components sets how the listed Components of the source Application are cloned
+	// +listType=map
+	// +listMapKey=name
+	Components []ComponentCloning `json:"components,omitempty"`
+
+	// DefaultMode is how the Components that aren't listed in Components are cloned
+	// +kubebuilder:default=Image
+	DefaultMode ComponentMode `json:"defaultMode,omitempty"`
+
+	// Snapshot is the name of the Snapshot of the source Application that the Components in the Snapshot mode take
+	// their image from. It must belong to the source Application. Defaults to the most recent Snapshot of the source
+	// Application.
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// Build configures how the build service builds the Components cloned from source
+	Build *BuildSettings `json:"build,omitempty"`
+
+	// IntegrationTests selects which IntegrationTestScenarios of the source Application are cloned.
+	// All of them are cloned when unset.
+	IntegrationTests *IntegrationTestSelection `json:"integrationTests,omitempty"`
+
+	// IntegrationTestOverrides rewrite the cloned IntegrationTestScenarios, in order
+	IntegrationTestOverrides []IntegrationTestOverride `json:"integrationTestOverrides,omitempty"`
+
+	// Environments controls cloning of the Environments used by the source Application
+	Environments *EnvironmentCloning `json:"environments,omitempty"`
+
+	// SnapshotEnvironmentBindings controls cloning of the source Application's SnapshotEnvironmentBindings
+	SnapshotEnvironmentBindings *SnapshotEnvironmentBindingCloning `json:"snapshotEnvironmentBindings,omitempty"`
+
+	// ExtraResources selects objects of other kinds that belong to the Application, e.g. ConfigMaps, Services
+	// or Routes, which are cloned along with it
+	ExtraResources []ExtraResource `json:"extraResources,omitempty"`
+
+	// ConfigMapOverrides rewrite the ConfigMaps cloned because the environment variables of the cloned
+	// Components refer to them
+	ConfigMapOverrides []ConfigMapOverride `json:"configMapOverrides,omitempty"`
+
+	// Secrets sets how the listed Secrets that the cloned Components refer to, as their Git credentials or in
+	// their environment variables, are cloned
+	Secrets []SecretCloning `json:"secrets,omitempty"`
+
+	// DefaultSecretStrategy is how the Secrets that aren't listed in Secrets are cloned
+	// +kubebuilder:default=Reference
+	DefaultSecretStrategy SecretStrategy `json:"defaultSecretStrategy,omitempty"`
+
+	// Sanitization rewrites the environment variables of the cloned Components, after the rules of the
+	// cluster-wide sanitization policy
+	Sanitization []SanitizationRule `json:"sanitization,omitempty"`
+
+	// GitOps commits the cloned resources as YAML manifests to a Git repository instead of creating them
+	GitOps *GitOpsTarget `json:"gitOps,omitempty"`
+}
+
+// ApplicationSource is where the cloned Application is read from. Exactly one of the fields must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.application) != has(self.bundle)",message="exactly one of application and bundle must be set"
+type ApplicationSource struct {
+	// Application references an Application living in a namespace
+	Application *ApplicationReference `json:"application,omitempty"`
+
+	// Bundle reads the Application from a bundle written by an ApplicationExport
+	Bundle *BundleReference `json:"bundle,omitempty"`
+}
+
+// ApplicationReference references an Application of the local cluster or of a remote cluster
+type ApplicationReference struct {
+	// Namespace of the Application
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Name of the Application
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Cluster points at the cluster the Application is cloned from. The cluster the controller runs in is used when unset.
+	Cluster *ClusterRef `json:"cluster,omitempty"`
+}
+
+// BundleReference references a bundle written by an ApplicationExport. Exactly one of ConfigMap and OCI must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.configMap) != has(self.oci)",message="exactly one of configMap and oci must be set"
+type BundleReference struct {
+	// Application is the name of the Application in the bundle. Defaults to the Application of the bundle.
+	Application string `json:"application,omitempty"`
+
+	// Namespace the resources of the bundle are read as if they were in. Defaults to the namespace of the
+	// ApplicationClone.
+	Namespace string `json:"namespace,omitempty"`
+
+	// ConfigMap references a ConfigMap, in the namespace of the ApplicationClone, holding the bundle
+	ConfigMap *ConfigMapBundleSource `json:"configMap,omitempty"`
+
+	// OCI references an OCI artifact holding the bundle, by tag or digest
+	OCI *OCIArtifact `json:"oci,omitempty"`
+}
+
+type ConfigMapBundleSource struct {
+	// Name of the ConfigMap
+	Name string `json:"name"`
+
+	// Key of the bundle in the ConfigMap's data. Defaults to "bundle.yaml".
+	Key string `json:"key,omitempty"`
+}
+
+// OCIArtifact references a bundle stored as an OCI artifact in a registry
+type OCIArtifact struct {
+	// Reference of the artifact, e.g. "quay.io/org/templates/billing-app:v1" or
+	// "quay.io/org/templates/billing-app@sha256:...".
+	Reference string `json:"reference"`
+
+	// CredentialsSecret is the name of a Secret, in the current namespace, of type kubernetes.io/dockerconfigjson
+	// holding the credentials for the registry. The registry is accessed anonymously when unset.
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+// ClusterRef references a Secret, in the namespace of the ApplicationClone, holding a kubeconfig for a remote cluster.
+type ClusterRef struct {
+	// SecretName is the name of the Secret holding the kubeconfig
+	SecretName string `json:"secretName"`
+
+	// Key is the key of the kubeconfig in the Secret's data. Defaults to "kubeconfig".
+	Key string `json:"key,omitempty"`
+}
+
+// ComponentMode is how a Component is cloned
+// +kubebuilder:validation:Enum=Source;Image;Snapshot;Skip
+type ComponentMode string
+
+const (
+	// ComponentModeSource builds the cloned Component from the source code of the source Component, which must
+	// have a Git source
+	ComponentModeSource ComponentMode = "Source"
+
+	// ComponentModeImage reuses the image of the source Component
+	ComponentModeImage ComponentMode = "Image"
+
+	// ComponentModeSnapshot uses the image of the Component in a Snapshot of the source Application
+	ComponentModeSnapshot ComponentMode = "Snapshot"
+
+	// ComponentModeSkip leaves the Component out of the clone
+	ComponentModeSkip ComponentMode = "Skip"
+)
+
+// BuildSettings configures how the build service builds the Components cloned from source. Unset fields are
+// taken from the settings of the ApplicationClone, then from the defaults of the controller.
+type BuildSettings struct {
+	// ImageVisibility is the visibility of the image repository generated for the Component
+	ImageVisibility ImageVisibility `json:"imageVisibility,omitempty"`
+
+	// InitialBuild triggers a build of the Component once it is created
+	InitialBuild *bool `json:"initialBuild,omitempty"`
+
+	// Annotations are added to the Component for the build service, e.g. to select its pipeline or to provision
+	// Pipelines as Code. They are merged with the annotations of the broader settings.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ImageVisibility is the visibility of an image repository
+// +kubebuilder:validation:Enum=Public;Private
+type ImageVisibility string
+
+const (
+	// ImageVisibilityPublic lets anyone pull the images
+	ImageVisibilityPublic ImageVisibility = "Public"
+
+	// ImageVisibilityPrivate restricts pulling the images to the credentials of the namespace
+	ImageVisibilityPrivate ImageVisibility = "Private"
+)
+
+// ComponentCloning sets how a Component of the source Application is cloned
+type ComponentCloning struct {
+	// Name of the Component in the source Application
+	Name string `json:"name"`
+
+	// Mode is how the Component is cloned
+	Mode ComponentMode `json:"mode"`
+
+	// Build overrides the build settings of the ApplicationClone for the Component, when built from source
+	Build *BuildSettings `json:"build,omitempty"`
+}
+
+// ExtraResource selects the objects of a kind that are cloned along with the Application. The kind must be allowed by
+// the controller, ConfigMaps, Services and Routes by default, and the controller must be allowed to list them in the
+// source namespace and to create them in the namespace of the ApplicationClone. Secrets are cloned through Secrets.
+// +kubebuilder:validation:XValidation:rule="!(self.apiVersion == 'v1' && self.kind == 'Secret')",message="Secrets can't be extra resources, list them in secrets instead"
+type ExtraResource struct {
+	// APIVersion of the objects, e.g. "v1" or "route.openshift.io/v1"
+	// +kubebuilder:validation:MinLength=1
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the objects, e.g. "ConfigMap"
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// Selector selects the objects of the source namespace that are cloned by their labels. An empty selector
+	// selects all of them.
+	Selector metav1.LabelSelector `json:"selector"`
+}
+
+// SecretStrategy is how a Secret referenced by the cloned Components is cloned
+// +kubebuilder:validation:Enum=Copy;Placeholder;Reference
+type SecretStrategy string
+
+const (
+	// SecretStrategyCopy copies the Secret with its values
+	SecretStrategyCopy SecretStrategy = "Copy"
+
+	// SecretStrategyPlaceholder creates a Secret with the keys of the source Secret and empty values, which the
+	// user fills in
+	SecretStrategyPlaceholder SecretStrategy = "Placeholder"
+
+	// SecretStrategyReference leaves the Secret out of the clone, for an external secret manager to create it
+	SecretStrategyReference SecretStrategy = "Reference"
+)
+
+// SecretCloning sets how a Secret referenced by the cloned Components is cloned
+type SecretCloning struct {
+	// Name of the Secret
+	Name string `json:"name"`
+
+	// Strategy the Secret is cloned with
+	Strategy SecretStrategy `json:"strategy"`
+}
+
+// SanitizationAction is what is done to the environment variables a SanitizationRule matches
+// +kubebuilder:validation:Enum=Replace;Drop;Flag
+type SanitizationAction string
+
+const (
+	// SanitizationActionReplace replaces the value of the variable
+	SanitizationActionReplace SanitizationAction = "Replace"
+
+	// SanitizationActionDrop leaves the variable out of the clone
+	SanitizationActionDrop SanitizationAction = "Drop"
+
+	// SanitizationActionFlag keeps the variable as it is, and only reports it in the status
+	SanitizationActionFlag SanitizationAction = "Flag"
+)
+
+// SanitizationRule matches the environment variables of the cloned Components by their name, their value, or both.
+// The first rule matching a variable applies to it.
+// +kubebuilder:validation:XValidation:rule="has(self.name) || has(self.value)",message="at least one of name and value must be set"
+type SanitizationRule struct {
+	// Name is a regular expression matching the whole name of the variable, e.g. "DB_.*"
+	Name string `json:"name,omitempty"`
+
+	// Value is a regular expression matching a part of the value of the variable, e.g. "prod\.example\.com".
+	// Variables read from a ConfigMap or a Secret have no value to match.
+	Value string `json:"value,omitempty"`
+
+	// Action applied to the matching variables
+	Action SanitizationAction `json:"action"`
+
+	// Replacement is the new value of the variables for the Replace action. When Value is set, only the parts of
+	// the value it matches are replaced, and the replacement may refer to its submatches, e.g. "${1}.example.org".
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// SanitizedField is an environment variable of a cloned Component that a SanitizationRule matched
+type SanitizedField struct {
+	// Component the variable belongs to
+	Component string `json:"component"`
+
+	// Env is the name of the variable
+	Env string `json:"env"`
+
+	// Action applied to the variable
+	Action SanitizationAction `json:"action"`
+}
+
+// PolicyViolation is a rule of a ClonePolicy that an ApplicationClone breaks
+type PolicyViolation struct {
+	// Policy is the name of the ClonePolicy
+	Policy string `json:"policy"`
+
+	// Message describes the violation
+	Message string `json:"message"`
+}
+
+// ApprovalRecord records an ApplicationCloneApproval of the namespace of the Application
+type ApprovalRecord struct {
+	// Name of the ApplicationCloneApproval
+	Name string `json:"name"`
+
+	// Approver is the user who approved the clone
+	Approver string `json:"approver"`
+
+	// ExpirationTime is when the approval expires
+	ExpirationTime metav1.Time `json:"expirationTime"`
+}
+
+// ConfigMapOverride replaces keys of a cloned ConfigMap
+type ConfigMapOverride struct {
+	// Name of the ConfigMap
+	Name string `json:"name"`
+
+	// Data overrides the values of the keys of the ConfigMap's data; the missing keys are added
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// IntegrationTestSelection filters the IntegrationTestScenarios that are cloned along with the Application.
+// A scenario is cloned when it matches every filter that is set.
+type IntegrationTestSelection struct {
+	// None skips cloning IntegrationTestScenarios altogether
+	None bool `json:"none,omitempty"`
+
+	// Include lists glob patterns (e.g. "e2e-*") of scenario names to clone. All names match when empty.
+	Include []string `json:"include,omitempty"`
+
+	// Exclude lists glob patterns of scenario names that are never cloned, even if they match Include
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Selector restricts cloning to the scenarios whose labels match
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Contexts restricts cloning to the scenarios that declare at least one of the listed contexts
+	Contexts []string `json:"contexts,omitempty"`
+}
+
+// IntegrationTestOverride replaces parameters of the cloned IntegrationTestScenarios whose name matches.
+// Parameters are matched by name; the ones missing from the source scenario are added.
+type IntegrationTestOverride struct {
+	// Name is a glob pattern of the scenario names this override applies to
+	Name string `json:"name"`
+
+	// ResolverParams override the scenario's resolverRef params, e.g. the git "revision" of the test pipeline
+	ResolverParams []ResolverParam `json:"resolverParams,omitempty"`
+
+	// Params override the parameters passed to the test pipeline
+	Params []PipelineParam `json:"params,omitempty"`
+
+	// Environment is the name of an Environment in the target namespace that the scenario is re-pointed to
+	Environment string `json:"environment,omitempty"`
+}
+
+// ResolverParam is a parameter of the resolver of a test pipeline
+type ResolverParam struct {
+	// Name of the parameter, e.g. "revision"
+	Name string `json:"name"`
+
+	// Value of the parameter
+	Value string `json:"value"`
+}
+
+// PipelineParam is a parameter of a test pipeline, holding either a string or an array
+type PipelineParam struct {
+	// Name of the parameter
+	Name string `json:"name"`
+
+	// Value of a string parameter
+	Value string `json:"value,omitempty"`
+
+	// Values of an array parameter
+	Values []string `json:"values,omitempty"`
+}
+
+// EnvironmentCloning describes how the Environments referenced by the source Application's
+// IntegrationTestScenarios and SnapshotEnvironmentBindings, and their parent Environments, are cloned.
+type EnvironmentCloning struct {
+	// Clone copies the referenced Environments into the current namespace
+	Clone bool `json:"clone,omitempty"`
+
+	// NamePrefix is prepended to the names of the cloned Environments
+	NamePrefix string `json:"namePrefix,omitempty"`
+
+	// NameSuffix is appended to the names of the cloned Environments
+	NameSuffix string `json:"nameSuffix,omitempty"`
+
+	// Mappings point references to a source Environment at an existing Environment in the current
+	// namespace. Mapped Environments are not cloned.
+	Mappings []EnvironmentMapping `json:"mappings,omitempty"`
+}
+
+type EnvironmentMapping struct {
+	// From is the name of the Environment in the source namespace
+	From string `json:"from"`
+
+	// To is the name of the Environment in the current namespace
+	To string `json:"to"`
+}
+
+// SnapshotEnvironmentBindingCloning describes how the source Application's SnapshotEnvironmentBindings are cloned.
+type SnapshotEnvironmentBindingCloning struct {
+	// Clone binds the cloned Application to the cloned or mapped Environments, using copies of the bound
+	// Snapshots. Bindings to Environments that are neither cloned nor mapped are skipped.
+	Clone bool `json:"clone,omitempty"`
+
+	// RewriteEnv lists the names of the environment variables of the bound Components that hold the name of an
+	// Environment or a Component, e.g. "BACKEND_COMPONENT". Their values are rewritten to the name of its clone;
+	// the other variables are carried over as they are.
+	RewriteEnv []string `json:"rewriteEnv,omitempty"`
+}
+
+// GitOpsTarget is a directory of a Git repository branch the cloned resources are written to
+type GitOpsTarget struct {
+	// URL of the repository, e.g. "https://github.com/org/gitops.git". Only HTTPS repositories are supported, as
+	// CredentialsSecret holds HTTP credentials.
+	// +kubebuilder:validation:XValidation:rule="self.matches('^https://[^/]+/.+')",message="url must be the HTTPS URL of a remote repository"
+	URL string `json:"url"`
+
+	// Branch the manifests are committed to. It is created if it doesn't exist.
+	// +kubebuilder:default=main
+	Branch string `json:"branch,omitempty"`
+
+	// Path of the directory holding the manifests, relative to the root of the repository. Defaults to
+	// <namespace>/<name> of the ApplicationClone. The directory is owned by the ApplicationClone: files of resources
+	// that are no longer cloned are removed.
+	// +kubebuilder:validation:XValidation:rule="self.matches('^[^/]+(/[^/]+)*$')",message="path must be a relative path without empty segments"
+	// +kubebuilder:validation:XValidation:rule="!self.matches('(^|/)[.][.]?(/|$)')",message="path must not have . or .. segments"
+	Path string `json:"path,omitempty"`
+
+	// CredentialsSecret is the name of a kubernetes.io/basic-auth Secret, in the namespace of the ApplicationClone,
+	// holding the credentials for the repository. Tokens are given as the password. The repository is accessed
+	// anonymously when unset.
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+}
+
+const (
+	// ReadyCondition tells whether the last attempt to clone the Application succeeded
+	ReadyCondition = "Ready"
+
+	// ClonedReason is the reason of the Ready condition when the Application was cloned
+	ClonedReason = "Cloned"
+
+	// CloneFailedReason is the reason of the Ready condition when the Application couldn't be cloned. The message
+	// of the condition holds the error.
+	CloneFailedReason = "CloneFailed"
+
+	// SecretsPendingReason is the reason of the Ready condition when the Application was cloned but placeholder
+	// Secrets still wait for their values
+	SecretsPendingReason = "SecretsPending"
+
+	// PolicyViolationReason is the reason of the Ready condition when ClonePolicies of the cluster prevented the
+	// Application from being cloned. The violations are listed in the status.
+	PolicyViolationReason = "PolicyViolation"
+
+	// PendingApprovalReason is the reason of the Ready condition when the Application is protected and no
+	// ApplicationCloneApproval of its namespace currently approves the clone
+	PendingApprovalReason = "PendingApproval"
+)
+
+// ApplicationCloneStatus defines the observed state of ApplicationClone
+type ApplicationCloneStatus struct {
+	// Conditions of the ApplicationClone
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Resources lists the resources of the last successful attempt
+	Resources []Resource `json:"resources,omitempty"`
+
+	// LastAttemptTime is when the Application was last cloned
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
+
+	// LastSuccessTime is when the Application was last cloned successfully
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+
+	// Commit is the SHA of the commit holding the manifests of the cloned resources, in GitOps mode
+	Commit string `json:"commit,omitempty"`
+
+	// PendingSecrets lists the placeholder Secrets of the namespace whose values aren't filled in yet
+	PendingSecrets []string `json:"pendingSecrets,omitempty"`
+
+	// Sanitized lists the environment variables of the cloned Components that sanitization rules matched
+	Sanitized []SanitizedField `json:"sanitized,omitempty"`
+
+	// PolicyViolations lists the rules of the ClonePolicies of the cluster that prevented the last attempt
+	PolicyViolations []PolicyViolation `json:"policyViolations,omitempty"`
+
+	// Approval is the ApplicationCloneApproval the last attempt to clone a protected Application was approved by
+	Approval *ApprovalRecord `json:"approval,omitempty"`
+}
+
+// Resource is a resource created by an ApplicationClone
+type Resource struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+//+genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`
+//+kubebuilder:printcolumn:name="Last Success",type=date,JSONPath=`.status.lastSuccessTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ApplicationClone clones an Application, along with its Components and related resources, into its namespace
+type ApplicationClone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ApplicationCloneSpec   `json:"spec,omitempty"`
+	Status ApplicationCloneStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ApplicationCloneList contains a list of ApplicationClone
+type ApplicationCloneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ApplicationClone `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ApplicationClone{}, &ApplicationCloneList{})
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-appstudio-redhat-com-v1beta1-applicationclone,mutating=false,failurePolicy=fail,sideEffects=None,groups=appstudio.redhat.com,resources=applicationclones,verbs=create;update,versions=v1beta1,name=vapplicationclone.kb.io,admissionReviewVersions=v1
+
+// SetupWebhookWithManager registers the conversion webhook of ApplicationClone with the manager, and its validating
+// webhook when the validator is set. The API server converts the ApplicationClones of every version to v1beta1
+// before validating them.
+func (r *ApplicationClone) SetupWebhookWithManager(mgr ctrl.Manager, validator admission.CustomValidator) error {
+	builder := ctrl.NewWebhookManagedBy(mgr).For(r)
+	if validator != nil {
+		builder = builder.WithValidator(validator)
+	}
+	return builder.Complete()
+}
@@ -0,0 +1,678 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationClone) DeepCopyInto(out *ApplicationClone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationClone.
+func (in *ApplicationClone) DeepCopy() *ApplicationClone {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationClone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationClone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationCloneList) DeepCopyInto(out *ApplicationCloneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ApplicationClone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationCloneList.
+func (in *ApplicationCloneList) DeepCopy() *ApplicationCloneList {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationCloneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ApplicationCloneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationCloneSpec) DeepCopyInto(out *ApplicationCloneSpec) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentCloning, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Build != nil {
+		in, out := &in.Build, &out.Build
+		*out = new(BuildSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrationTests != nil {
+		in, out := &in.IntegrationTests, &out.IntegrationTests
+		*out = new(IntegrationTestSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IntegrationTestOverrides != nil {
+		in, out := &in.IntegrationTestOverrides, &out.IntegrationTestOverrides
+		*out = make([]IntegrationTestOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = new(EnvironmentCloning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotEnvironmentBindings != nil {
+		in, out := &in.SnapshotEnvironmentBindings, &out.SnapshotEnvironmentBindings
+		*out = new(SnapshotEnvironmentBindingCloning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraResources != nil {
+		in, out := &in.ExtraResources, &out.ExtraResources
+		*out = make([]ExtraResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigMapOverrides != nil {
+		in, out := &in.ConfigMapOverrides, &out.ConfigMapOverrides
+		*out = make([]ConfigMapOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]SecretCloning, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sanitization != nil {
+		in, out := &in.Sanitization, &out.Sanitization
+		*out = make([]SanitizationRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.GitOps != nil {
+		in, out := &in.GitOps, &out.GitOps
+		*out = new(GitOpsTarget)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationCloneSpec.
+func (in *ApplicationCloneSpec) DeepCopy() *ApplicationCloneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationCloneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationCloneStatus) DeepCopyInto(out *ApplicationCloneStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]Resource, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingSecrets != nil {
+		in, out := &in.PendingSecrets, &out.PendingSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sanitized != nil {
+		in, out := &in.Sanitized, &out.Sanitized
+		*out = make([]SanitizedField, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyViolations != nil {
+		in, out := &in.PolicyViolations, &out.PolicyViolations
+		*out = make([]PolicyViolation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalRecord)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationCloneStatus.
+func (in *ApplicationCloneStatus) DeepCopy() *ApplicationCloneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationCloneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationReference) DeepCopyInto(out *ApplicationReference) {
+	*out = *in
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(ClusterRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationReference.
+func (in *ApplicationReference) DeepCopy() *ApplicationReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSource) DeepCopyInto(out *ApplicationSource) {
+	*out = *in
+	if in.Application != nil {
+		in, out := &in.Application, &out.Application
+		*out = new(ApplicationReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bundle != nil {
+		in, out := &in.Bundle, &out.Bundle
+		*out = new(BundleReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSource.
+func (in *ApplicationSource) DeepCopy() *ApplicationSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalRecord) DeepCopyInto(out *ApprovalRecord) {
+	*out = *in
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalRecord.
+func (in *ApprovalRecord) DeepCopy() *ApprovalRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildSettings) DeepCopyInto(out *BuildSettings) {
+	*out = *in
+	if in.InitialBuild != nil {
+		in, out := &in.InitialBuild, &out.InitialBuild
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildSettings.
+func (in *BuildSettings) DeepCopy() *BuildSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BundleReference) DeepCopyInto(out *BundleReference) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapBundleSource)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIArtifact)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BundleReference.
+func (in *BundleReference) DeepCopy() *BundleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BundleReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRef) DeepCopyInto(out *ClusterRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRef.
+func (in *ClusterRef) DeepCopy() *ClusterRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentCloning) DeepCopyInto(out *ComponentCloning) {
+	*out = *in
+	if in.Build != nil {
+		in, out := &in.Build, &out.Build
+		*out = new(BuildSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentCloning.
+func (in *ComponentCloning) DeepCopy() *ComponentCloning {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentCloning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapBundleSource) DeepCopyInto(out *ConfigMapBundleSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapBundleSource.
+func (in *ConfigMapBundleSource) DeepCopy() *ConfigMapBundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapBundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapOverride) DeepCopyInto(out *ConfigMapOverride) {
+	*out = *in
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapOverride.
+func (in *ConfigMapOverride) DeepCopy() *ConfigMapOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentCloning) DeepCopyInto(out *EnvironmentCloning) {
+	*out = *in
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]EnvironmentMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentCloning.
+func (in *EnvironmentCloning) DeepCopy() *EnvironmentCloning {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentCloning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentMapping) DeepCopyInto(out *EnvironmentMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentMapping.
+func (in *EnvironmentMapping) DeepCopy() *EnvironmentMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtraResource) DeepCopyInto(out *ExtraResource) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraResource.
+func (in *ExtraResource) DeepCopy() *ExtraResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsTarget) DeepCopyInto(out *GitOpsTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsTarget.
+func (in *GitOpsTarget) DeepCopy() *GitOpsTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTestOverride) DeepCopyInto(out *IntegrationTestOverride) {
+	*out = *in
+	if in.ResolverParams != nil {
+		in, out := &in.ResolverParams, &out.ResolverParams
+		*out = make([]ResolverParam, len(*in))
+		copy(*out, *in)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]PipelineParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTestOverride.
+func (in *IntegrationTestOverride) DeepCopy() *IntegrationTestOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTestOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationTestSelection) DeepCopyInto(out *IntegrationTestSelection) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Contexts != nil {
+		in, out := &in.Contexts, &out.Contexts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationTestSelection.
+func (in *IntegrationTestSelection) DeepCopy() *IntegrationTestSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationTestSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIArtifact) DeepCopyInto(out *OCIArtifact) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIArtifact.
+func (in *OCIArtifact) DeepCopy() *OCIArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineParam) DeepCopyInto(out *PipelineParam) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineParam.
+func (in *PipelineParam) DeepCopy() *PipelineParam {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyViolation) DeepCopyInto(out *PolicyViolation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyViolation.
+func (in *PolicyViolation) DeepCopy() *PolicyViolation {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyViolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolverParam) DeepCopyInto(out *ResolverParam) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverParam.
+func (in *ResolverParam) DeepCopy() *ResolverParam {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolverParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Resource) DeepCopyInto(out *Resource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resource.
+func (in *Resource) DeepCopy() *Resource {
+	if in == nil {
+		return nil
+	}
+	out := new(Resource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SanitizationRule) DeepCopyInto(out *SanitizationRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SanitizationRule.
+func (in *SanitizationRule) DeepCopy() *SanitizationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SanitizationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SanitizedField) DeepCopyInto(out *SanitizedField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SanitizedField.
+func (in *SanitizedField) DeepCopy() *SanitizedField {
+	if in == nil {
+		return nil
+	}
+	out := new(SanitizedField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretCloning) DeepCopyInto(out *SecretCloning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretCloning.
+func (in *SecretCloning) DeepCopy() *SecretCloning {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretCloning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotEnvironmentBindingCloning) DeepCopyInto(out *SnapshotEnvironmentBindingCloning) {
+	*out = *in
+	if in.RewriteEnv != nil {
+		in, out := &in.RewriteEnv, &out.RewriteEnv
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotEnvironmentBindingCloning.
+func (in *SnapshotEnvironmentBindingCloning) DeepCopy() *SnapshotEnvironmentBindingCloning {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotEnvironmentBindingCloning)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	appstudioredhatcomv1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(appstudioredhatcomv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(hasApplicationAPI.AddToScheme(scheme))
+	utilruntime.Must(integrationtestapi.AddToScheme(scheme))
+}
+
+type options struct {
+	file       string
+	input      string
+	namespace  string
+	kubeconfig string
+}
+
+// run runs the command, writing its output to out
+func run(ctx context.Context, command string, args []string, out io.Writer) error {
+	o := &options{}
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.StringVar(&o.file, "f", "", "ApplicationClone manifest")
+	flags.StringVar(&o.input, "input", "", "bundle written by an ApplicationExport to clone from, instead of the cluster")
+	flags.StringVar(&o.namespace, "n", "", "namespace the Application is cloned into")
+	flags.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig file")
+
+	switch command {
+	case "plan", "apply", "render":
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+	case "help", "-h", "--help":
+		fmt.Fprint(out, usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q, see clonectl help", command)
+	}
+
+	applicationClone := &appstudioredhatcomv1alpha1.ApplicationClone{}
+	if o.file != "" {
+		var err error
+		applicationClone, err = readApplicationClone(o.file)
+		if err != nil {
+			return err
+		}
+	} else if command != "render" {
+		return fmt.Errorf("-f is required")
+	}
+
+	var b *bundle.Bundle
+	if o.input != "" {
+		data, err := os.ReadFile(o.input)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", o.input, err)
+		}
+		b, err = bundle.Unmarshal(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if command == "render" {
+		if b == nil {
+			return fmt.Errorf("--input is required")
+		}
+		namespace := o.namespace
+		if namespace == "" {
+			namespace = applicationClone.Namespace
+		}
+		if namespace == "" {
+			return fmt.Errorf("-n is required")
+		}
+		return render(ctx, out, applicationClone, b, namespace)
+	}
+
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: o.kubeconfig, Precedence: clientcmd.NewDefaultClientConfigLoadingRules().Precedence},
+		&clientcmd.ConfigOverrides{},
+	)
+
+	namespace := o.namespace
+	if namespace == "" {
+		namespace = applicationClone.Namespace
+	}
+	if namespace == "" {
+		var err error
+		namespace, _, err = config.Namespace()
+		if err != nil {
+			return fmt.Errorf("error reading namespace from kubeconfig: %w", err)
+		}
+	}
+
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("error reading kubeconfig: %w", err)
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	plan, err := planClone(ctx, applicationClone, b, c, c, namespace)
+	if err != nil {
+		return err
+	}
+
+	if command == "plan" {
+		return printPlan(ctx, out, plan, c)
+	}
+
+	err = (&clone.Applier{Client: c}).Apply(ctx, plan)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "cloned %d resources into %s\n", len(plan.Objects), namespace)
+	return nil
+}
+
+// readApplicationClone reads the ApplicationClone manifest. A v1beta1 manifest is converted to v1alpha1, which
+// the planning works on.
+func readApplicationClone(path string) (*appstudioredhatcomv1alpha1.ApplicationClone, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	typeMeta := &metav1.TypeMeta{}
+	err = yaml.Unmarshal(data, typeMeta)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", path, err)
+	}
+	if typeMeta.Kind != "ApplicationClone" {
+		return nil, fmt.Errorf("%s is not an ApplicationClone but a %q", path, typeMeta.Kind)
+	}
+
+	applicationClone := &appstudioredhatcomv1alpha1.ApplicationClone{}
+	switch typeMeta.APIVersion {
+	case appstudioredhatcomv1alpha1.GroupVersion.String():
+		err = yaml.UnmarshalStrict(data, applicationClone)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+	case appstudioredhatcomv1beta1.GroupVersion.String():
+		hub := &appstudioredhatcomv1beta1.ApplicationClone{}
+		err = yaml.UnmarshalStrict(data, hub)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		err = applicationClone.ConvertFrom(hub)
+		if err != nil {
+			return nil, fmt.Errorf("error converting %s to %s: %w", path, appstudioredhatcomv1alpha1.GroupVersion, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s is an ApplicationClone of the unknown version %q", path, typeMeta.APIVersion)
+	}
+
+	return applicationClone, nil
+}
+
+// planClone plans the ApplicationClone, reading the Application from the bundle when one is given and from
+// the source otherwise.
+func planClone(ctx context.Context, applicationClone *appstudioredhatcomv1alpha1.ApplicationClone, b *bundle.Bundle, source, target client.Reader, namespace string) (*clone.Plan, error) {
+	spec := applicationClone.Spec.DeepCopy()
+
+	if spec.From.ClusterRef != nil || spec.From.Bundle != nil {
+		return nil, fmt.Errorf("from.clusterRef and from.bundle are not supported, use --kubeconfig or --input instead")
+	}
+
+	if b != nil {
+		if spec.From.Name == "" {
+			spec.From.Name = b.Application.Name
+		}
+		if spec.From.Name != b.Application.Name {
+			return nil, fmt.Errorf("bundle holds application %s, not %s", b.Application.Name, spec.From.Name)
+		}
+		if spec.From.Namespace == "" {
+			spec.From.Namespace = namespace
+		}
+		source = b.Reader(scheme, spec.From.Namespace)
+	}
+
+	if spec.From.Namespace == "" || spec.From.Name == "" {
+		return nil, fmt.Errorf("from.namespace and from.name are required unless --input is given")
+	}
+
+	planner := &clone.Planner{Source: source, Target: target, Scheme: scheme}
+	return planner.Plan(ctx, namespace, spec)
+}
+
+// printPlan lists the objects of the plan, and whether they would be created or already exist in the target
+func printPlan(ctx context.Context, out io.Writer, plan *clone.Plan, target client.Reader) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ACTION\tKIND\tNAMESPACE\tNAME")
+
+	for _, obj := range plan.Objects {
+		action := "create"
+		existing := obj.DeepCopyObject().(client.Object)
+		err := target.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		if err == nil {
+			action = "exists"
+		} else if !errors.IsNotFound(err) {
+			return fmt.Errorf("error reading %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", action, obj.GetObjectKind().GroupVersionKind().Kind, obj.GetNamespace(), obj.GetName())
+	}
+
+	return w.Flush()
+}
+
+// render writes the objects of the ApplicationClone, cloned from the bundle, as YAML
+func render(ctx context.Context, out io.Writer, applicationClone *appstudioredhatcomv1alpha1.ApplicationClone, b *bundle.Bundle, namespace string) error {
+	plan, err := planClone(ctx, applicationClone, b, nil, emptyNamespace{}, namespace)
+	if err != nil {
+		return err
+	}
+
+	data, err := plan.Render()
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write(data)
+	return err
+}
+
+// emptyNamespace is the target of offline renders: it holds no objects
+type emptyNamespace struct{}
+
+func (emptyNamespace) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	return errors.NewNotFound(schema.GroupResource{}, key.Name)
+}
+
+func (emptyNamespace) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+)
+
+const applicationCloneManifest = `apiVersion: appstudio.redhat.com/v1alpha1
+kind: ApplicationClone
+metadata:
+  name: billing-app
+  namespace: bar
+spec:
+  from:
+    name: billing-app
+  componentSources:
+  - name: c1
+`
+
+const applicationCloneV1beta1Manifest = `apiVersion: appstudio.redhat.com/v1beta1
+kind: ApplicationClone
+metadata:
+  name: billing-app
+  namespace: bar
+spec:
+  source:
+    application:
+      namespace: foo
+      name: billing-app
+  components:
+  - name: c1
+    mode: Source
+`
+
+func newBillingAppBundle() *bundle.Bundle {
+	b := bundle.New(&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "billing-app"}})
+	b.AddComponent(&hasApplicationAPI.Component{
+		ObjectMeta: metav1.ObjectMeta{Name: "c1"},
+		Spec: hasApplicationAPI.ComponentSpec{
+			ComponentName: "c1",
+			Application:   "billing-app",
+			Source: hasApplicationAPI.ComponentSource{
+				ComponentSourceUnion: hasApplicationAPI.ComponentSourceUnion{
+					GitSource: &hasApplicationAPI.GitSource{URL: "github.com/foo/c1"},
+				},
+			},
+		},
+	})
+	return b
+}
+
+func writeFile(dir, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+	return path
+}
+
+var _ = Describe("clonectl", func() {
+
+	var input, file string
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		data, err := newBillingAppBundle().Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		input = writeFile(dir, "bundle.yaml", data)
+		file = writeFile(dir, "clone.yaml", []byte(applicationCloneManifest))
+	})
+
+	It("Should render a bundle offline", func() {
+		out := &bytes.Buffer{}
+		Expect(run(context.Background(), "render", []string{"--input", input, "-f", file}, out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("kind: Application\n"))
+		Expect(out.String()).To(ContainSubstring("kind: Component\n"))
+		Expect(out.String()).To(ContainSubstring("url: github.com/foo/c1"))
+		Expect(out.String()).To(ContainSubstring("namespace: bar"))
+
+		out.Reset()
+		Expect(run(context.Background(), "render", []string{"--input", input, "-n", "baz"}, out)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("namespace: baz"))
+	})
+
+	It("Should read v1beta1 ApplicationClones", func() {
+		applicationClone, err := readApplicationClone(writeFile(GinkgoT().TempDir(), "clone.yaml", []byte(applicationCloneV1beta1Manifest)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applicationClone.Spec.From.Name).To(Equal("billing-app"))
+		Expect(applicationClone.Spec.From.Namespace).To(Equal("foo"))
+		Expect(applicationClone.Spec.ComponentSources).To(Equal([]appstudioredhatcomv1alpha1.ComponentSource{{Name: "c1", Mode: appstudioredhatcomv1alpha1.ComponentModeSource}}))
+
+		_, err = readApplicationClone(writeFile(GinkgoT().TempDir(), "clone.yaml", []byte(strings.Replace(applicationCloneManifest, "v1alpha1", "v2", 1))))
+		Expect(err).To(MatchError(ContainSubstring(`unknown version "appstudio.redhat.com/v2"`)))
+	})
+
+	It("Should reject incomplete commands", func() {
+		Expect(run(context.Background(), "render", []string{"-f", file}, &bytes.Buffer{})).To(MatchError("--input is required"))
+		Expect(run(context.Background(), "render", []string{"--input", input}, &bytes.Buffer{})).To(MatchError("-n is required"))
+		Expect(run(context.Background(), "plan", nil, &bytes.Buffer{})).To(MatchError("-f is required"))
+		Expect(run(context.Background(), "destroy", nil, &bytes.Buffer{})).To(MatchError(ContainSubstring("unknown command")))
+
+		_, err := readApplicationClone(input)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should tell which resources already exist", func() {
+		target := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&hasApplicationAPI.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "bar"},
+		}).Build()
+
+		applicationClone, err := readApplicationClone(file)
+		Expect(err).NotTo(HaveOccurred())
+
+		plan, err := planClone(context.Background(), applicationClone, newBillingAppBundle(), nil, target, "bar")
+		Expect(err).NotTo(HaveOccurred())
+
+		out := &bytes.Buffer{}
+		Expect(printPlan(context.Background(), out, plan, target)).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`exists +Application +bar +billing-app`))
+		Expect(out.String()).To(MatchRegexp(`create +Component +bar +c1`))
+
+		applicationClone.Spec.From.ClusterRef = &appstudioredhatcomv1alpha1.ClusterRef{SecretName: "remote"}
+		_, err = planClone(context.Background(), applicationClone, newBillingAppBundle(), nil, target, "bar")
+		Expect(err).To(MatchError(ContainSubstring("not supported")))
+	})
+})
@@ -0,0 +1,55 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// clonectl plans and applies ApplicationClones without the controller, and renders them offline.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+)
+
+const usage = `clonectl plans and applies ApplicationClones without the controller.
+
+Usage:
+  clonectl plan   -f clone.yaml [--input bundle.yaml] [-n namespace] [--kubeconfig path]
+      Lists the resources the ApplicationClone would create in the namespace.
+  clonectl apply  -f clone.yaml [--input bundle.yaml] [-n namespace] [--kubeconfig path]
+      Creates the resources of the ApplicationClone in the namespace.
+  clonectl render --input bundle.yaml [-f clone.yaml] -n namespace
+      Writes the resources of the ApplicationClone as YAML, without accessing a cluster.
+
+The Application is read from the cluster, or from the bundle written by an ApplicationExport given
+with --input. The namespace defaults to the namespace of the ApplicationClone, then to the namespace
+of the current kubeconfig context.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	err := run(context.Background(), os.Args[1], os.Args[2:], os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
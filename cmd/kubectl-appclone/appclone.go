@@ -0,0 +1,474 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	appstudioredhatcomv1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+)
+
+// resyncAnnotation is set to the time a resync was requested at. The controller reconciles an
+// ApplicationClone whenever its annotations change.
+const resyncAnnotation = "clone.appstudio.redhat.com/resync"
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(appstudioredhatcomv1beta1.AddToScheme(scheme))
+	utilruntime.Must(hasApplicationAPI.AddToScheme(scheme))
+	utilruntime.Must(integrationtestapi.AddToScheme(scheme))
+}
+
+// clonedLists returns empty lists of the kinds an ApplicationClone creates
+func clonedLists() []client.ObjectList {
+	return []client.ObjectList{
+		&hasApplicationAPI.ApplicationList{},
+		&hasApplicationAPI.ComponentList{},
+		&hasApplicationAPI.EnvironmentList{},
+		&integrationtestapi.IntegrationTestScenarioList{},
+		&hasApplicationAPI.SnapshotList{},
+		&hasApplicationAPI.SnapshotEnvironmentBindingList{},
+	}
+}
+
+// run runs the command, writing its output to out
+func run(ctx context.Context, command string, args []string, out io.Writer) error {
+	var namespace, kubeconfig string
+	var allNamespaces bool
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.StringVar(&namespace, "n", "", "namespace")
+	flags.StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig file")
+	if command == "list" {
+		flags.BoolVar(&allNamespaces, "A", false, "list the ApplicationClones of all namespaces")
+	}
+
+	switch command {
+	case "list", "status", "lineage", "resync":
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+	case "help", "-h", "--help":
+		fmt.Fprint(out, usage)
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q, see kubectl appclone help", command)
+	}
+
+	if command != "list" && flags.NArg() != 1 {
+		return fmt.Errorf("%s takes exactly one name", command)
+	}
+
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig, Precedence: clientcmd.NewDefaultClientConfigLoadingRules().Precedence},
+		&clientcmd.ConfigOverrides{},
+	)
+	if namespace == "" {
+		var err error
+		namespace, _, err = config.Namespace()
+		if err != nil {
+			return fmt.Errorf("error reading namespace from kubeconfig: %w", err)
+		}
+	}
+	if allNamespaces {
+		namespace = ""
+	}
+
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("error reading kubeconfig: %w", err)
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("error creating client: %w", err)
+	}
+
+	switch command {
+	case "list":
+		return listClones(ctx, out, c, namespace)
+	case "status":
+		return printStatus(ctx, out, c, namespace, flags.Arg(0))
+	case "lineage":
+		return printLineage(ctx, out, c, namespace, flags.Arg(0))
+	default:
+		err = resync(ctx, c, namespace, flags.Arg(0), time.Now())
+		if err == nil {
+			fmt.Fprintf(out, "applicationclone %s/%s resync requested\n", namespace, flags.Arg(0))
+		}
+		return err
+	}
+}
+
+// listClones lists the ApplicationClones of the namespace, or of all namespaces when namespace is empty
+func listClones(ctx context.Context, out io.Writer, c client.Reader, namespace string) error {
+	clones := &appstudioredhatcomv1beta1.ApplicationCloneList{}
+	err := c.List(ctx, clones, &client.ListOptions{Namespace: namespace})
+	if err != nil {
+		return fmt.Errorf("error listing applicationclones: %w", err)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tSOURCE\tMODE\tRESOURCES\tREADY\tLAST SYNC\tMESSAGE")
+	for _, applicationClone := range clones.Items {
+		mode := "direct"
+		if applicationClone.Spec.GitOps != nil {
+			mode = "gitops"
+		}
+		ready, message := readiness(&applicationClone)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n", applicationClone.Namespace, applicationClone.Name,
+			describeSource(applicationClone.Spec.Source), mode, len(applicationClone.Status.Resources), ready,
+			formatTime(applicationClone.Status.LastSuccessTime), orNone(truncate(message, 60)))
+	}
+	return w.Flush()
+}
+
+// printStatus shows the status of the ApplicationClone and of each of its resources
+func printStatus(ctx context.Context, out io.Writer, c client.Reader, namespace, name string) error {
+	applicationClone := &appstudioredhatcomv1beta1.ApplicationClone{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, applicationClone)
+	if err != nil {
+		return fmt.Errorf("error reading applicationclone %s: %w", name, err)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", applicationClone.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", applicationClone.Namespace)
+	fmt.Fprintf(w, "Source:\t%s\n", describeSource(applicationClone.Spec.Source))
+	if gitOps := applicationClone.Spec.GitOps; gitOps != nil {
+		fmt.Fprintf(w, "Repository:\t%s\n", gitOps.URL)
+		fmt.Fprintf(w, "Commit:\t%s\n", orNone(applicationClone.Status.Commit))
+	}
+	ready, message := readiness(applicationClone)
+	fmt.Fprintf(w, "Ready:\t%s\n", ready)
+	fmt.Fprintf(w, "Message:\t%s\n", orNone(message))
+	fmt.Fprintf(w, "Last attempt:\t%s\n", formatTime(applicationClone.Status.LastAttemptTime))
+	fmt.Fprintf(w, "Last sync:\t%s\n", formatTime(applicationClone.Status.LastSuccessTime))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// The resources of the last sync, and the ones labeled by the ApplicationClone in case the
+	// status is behind
+	statuses := map[appstudioredhatcomv1beta1.Resource]string{}
+	for _, resource := range applicationClone.Status.Resources {
+		statuses[resource] = ""
+	}
+	for _, list := range clonedLists() {
+		err = c.List(ctx, list, client.InNamespace(namespace), client.MatchingLabels{clone.CloneLabel: name})
+		if err != nil {
+			return fmt.Errorf("error listing cloned resources: %w", err)
+		}
+		err = meta.EachListItem(list, func(o runtime.Object) error {
+			obj := o.(client.Object)
+			gvk, err := apiutil.GVKForObject(obj, scheme)
+			if err != nil {
+				return err
+			}
+			statuses[appstudioredhatcomv1beta1.Resource{Kind: gvk.Kind, Name: obj.GetName()}] = ""
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for resource := range statuses {
+		statuses[resource], err = resourceStatus(ctx, c, applicationClone, resource)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tSTATUS")
+	for _, resource := range sortedResources(statuses) {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", resource.Kind, resource.Name, statuses[resource])
+	}
+	return w.Flush()
+}
+
+// resourceStatus tells whether the resource of the ApplicationClone was cloned, existed before, or is
+// missing from its namespace
+func resourceStatus(ctx context.Context, c client.Reader, applicationClone *appstudioredhatcomv1beta1.ApplicationClone, resource appstudioredhatcomv1beta1.Resource) (string, error) {
+	var obj client.Object
+	for _, gv := range []schema.GroupVersion{hasApplicationAPI.GroupVersion, integrationtestapi.GroupVersion} {
+		o, err := scheme.New(gv.WithKind(resource.Kind))
+		if err == nil {
+			obj = o.(client.Object)
+			break
+		}
+	}
+	if obj == nil {
+		return "unknown kind", nil
+	}
+
+	err := c.Get(ctx, types.NamespacedName{Namespace: applicationClone.Namespace, Name: resource.Name}, obj)
+	switch {
+	case errors.IsNotFound(err) && applicationClone.Spec.GitOps != nil:
+		// the resource is created once the commit is synced
+		return "pending", nil
+	case errors.IsNotFound(err):
+		return "missing", nil
+	case err != nil:
+		return "", fmt.Errorf("error reading %s %s: %w", resource.Kind, resource.Name, err)
+	case obj.GetLabels()[clone.CloneLabel] == applicationClone.Name:
+		return "cloned", nil
+	default:
+		// resources that already existed are left as they are
+		return "pre-existing", nil
+	}
+}
+
+// application is a node of the lineage tree
+type application struct {
+	namespace string
+	name      string
+	clone     string
+	parent    *application
+	children  []*application
+}
+
+func (a *application) String() string {
+	return a.name + " in " + a.namespace
+}
+
+// printLineage shows the chain of clones leading to the Application, and the clones made from it.
+// A Component is resolved to its Application.
+func printLineage(ctx context.Context, out io.Writer, c client.Reader, namespace, name string) error {
+	kind, name, found := strings.Cut(name, "/")
+	if !found {
+		kind, name = "application", kind
+	}
+
+	switch strings.ToLower(kind) {
+	case "application", "applications", "app":
+	case "component", "components", "comp":
+		component := &hasApplicationAPI.Component{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, component)
+		if err != nil {
+			return fmt.Errorf("error reading component %s: %w", name, err)
+		}
+		if cloneName, ok := component.Labels[clone.CloneLabel]; ok {
+			fmt.Fprintf(out, "Component %s was cloned by ApplicationClone %s", name, cloneName)
+			if sourceNamespace, ok := component.Labels[clone.SourceNamespaceLabel]; ok {
+				fmt.Fprintf(out, " from %s in %s", component.Labels[clone.SourceApplicationLabel], sourceNamespace)
+			}
+			fmt.Fprintln(out)
+		} else {
+			fmt.Fprintf(out, "Component %s wasn't cloned\n", name)
+		}
+		name = component.Spec.Application
+	default:
+		return fmt.Errorf("unsupported kind %q, expected application or component", kind)
+	}
+
+	applications := &hasApplicationAPI.ApplicationList{}
+	err := c.List(ctx, applications)
+	if err != nil {
+		return fmt.Errorf("error listing applications: %w", err)
+	}
+
+	nodes := map[types.NamespacedName]*application{}
+	for _, app := range applications.Items {
+		nodes[types.NamespacedName{Namespace: app.Namespace, Name: app.Name}] = &application{
+			namespace: app.Namespace,
+			name:      app.Name,
+			clone:     app.Labels[clone.CloneLabel],
+		}
+	}
+
+	selected, ok := nodes[types.NamespacedName{Namespace: namespace, Name: name}]
+	if !ok {
+		return fmt.Errorf("application %s not found in %s", name, namespace)
+	}
+
+	for _, app := range applications.Items {
+		sourceNamespace, ok := app.Labels[clone.SourceNamespaceLabel]
+		if !ok {
+			continue
+		}
+		sourceName := types.NamespacedName{Namespace: sourceNamespace, Name: app.Labels[clone.SourceApplicationLabel]}
+		parent, ok := nodes[sourceName]
+		if !ok {
+			// the source was deleted, or lives in another cluster
+			parent = &application{namespace: sourceName.Namespace, name: sourceName.Name}
+			nodes[sourceName] = parent
+		}
+		child := nodes[types.NamespacedName{Namespace: app.Namespace, Name: app.Name}]
+		child.parent = parent
+		parent.children = append(parent.children, child)
+	}
+
+	// Walk up to the root, guarding against cycles
+	chain := []*application{selected}
+	seen := map[*application]bool{selected: true}
+	for root := selected; root.parent != nil && !seen[root.parent]; root = root.parent {
+		chain = append([]*application{root.parent}, chain...)
+		seen[root.parent] = true
+	}
+
+	links := []string{chain[0].String()}
+	for i := 1; i < len(chain); i++ {
+		if chain[i].name == chain[i-1].name {
+			links = append(links, chain[i].namespace)
+		} else {
+			links = append(links, chain[i].String())
+		}
+	}
+	fmt.Fprintln(out, strings.Join(links, " -> "))
+	fmt.Fprintln(out)
+
+	printTree(out, chain[0], selected, "", "", map[*application]bool{})
+	return nil
+}
+
+// printTree prints the application and the clones made from it
+func printTree(out io.Writer, app, selected *application, prefix, childPrefix string, seen map[*application]bool) {
+	line := prefix + app.String()
+	if app.clone != "" {
+		line += " (ApplicationClone " + app.clone + ")"
+	}
+	if app == selected {
+		line += " *"
+	}
+	fmt.Fprintln(out, line)
+
+	if seen[app] {
+		return
+	}
+	seen[app] = true
+
+	sort.Slice(app.children, func(i, j int) bool { return app.children[i].String() < app.children[j].String() })
+	for i, child := range app.children {
+		if i == len(app.children)-1 {
+			printTree(out, child, selected, childPrefix+"└── ", childPrefix+"    ", seen)
+		} else {
+			printTree(out, child, selected, childPrefix+"├── ", childPrefix+"│   ", seen)
+		}
+	}
+}
+
+// resync makes the controller reconcile the ApplicationClone again
+func resync(ctx context.Context, c client.Client, namespace, name string, now time.Time) error {
+	applicationClone := &appstudioredhatcomv1beta1.ApplicationClone{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, applicationClone)
+	if err != nil {
+		return fmt.Errorf("error reading applicationclone %s: %w", name, err)
+	}
+
+	patch := client.MergeFrom(applicationClone.DeepCopy())
+	if applicationClone.Annotations == nil {
+		applicationClone.Annotations = map[string]string{}
+	}
+	applicationClone.Annotations[resyncAnnotation] = now.Format(time.RFC3339Nano)
+
+	err = c.Patch(ctx, applicationClone, patch)
+	if err != nil {
+		return fmt.Errorf("error requesting resync of applicationclone %s: %w", name, err)
+	}
+	return nil
+}
+
+// describeSource describes where an ApplicationClone reads the Application from
+func describeSource(source appstudioredhatcomv1beta1.ApplicationSource) string {
+	switch {
+	case source.Bundle != nil && source.Bundle.ConfigMap != nil:
+		return "bundle in configmap/" + source.Bundle.ConfigMap.Name
+	case source.Bundle != nil && source.Bundle.OCI != nil:
+		return "bundle " + source.Bundle.OCI.Reference
+	case source.Application != nil && source.Application.Cluster != nil:
+		return source.Application.Namespace + "/" + source.Application.Name + " on cluster of secret/" + source.Application.Cluster.SecretName
+	case source.Application != nil:
+		return source.Application.Namespace + "/" + source.Application.Name
+	default:
+		return "<none>"
+	}
+}
+
+// readiness returns the status of the Ready condition of the ApplicationClone, with its reason when the clone
+// isn't ready, and its message
+func readiness(applicationClone *appstudioredhatcomv1beta1.ApplicationClone) (string, string) {
+	ready := meta.FindStatusCondition(applicationClone.Status.Conditions, appstudioredhatcomv1beta1.ReadyCondition)
+	switch {
+	case ready == nil:
+		return string(metav1.ConditionUnknown), ""
+	case ready.Status == metav1.ConditionTrue:
+		return string(ready.Status), ready.Message
+	default:
+		return string(ready.Status) + " (" + ready.Reason + ")", ready.Message
+	}
+}
+
+func sortedResources(m map[appstudioredhatcomv1beta1.Resource]string) []appstudioredhatcomv1beta1.Resource {
+	resources := make([]appstudioredhatcomv1beta1.Resource, 0, len(m))
+	for resource := range m {
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Kind != resources[j].Kind {
+			return resources[i].Kind < resources[j].Kind
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	return resources
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return "<none>"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-3] + "..."
+}
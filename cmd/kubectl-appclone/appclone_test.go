@@ -0,0 +1,184 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appstudioredhatcomv1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+)
+
+// synced is the time the ApplicationClones of the lineage were last synced at
+var synced = metav1.NewTime(time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC))
+
+func clonedLabels(cloneName, sourceNamespace, sourceApplication string) map[string]string {
+	return map[string]string{
+		clone.CloneLabel:             cloneName,
+		clone.SourceNamespaceLabel:   sourceNamespace,
+		clone.SourceApplicationLabel: sourceApplication,
+	}
+}
+
+// newLineage returns billing-app in ns-a, cloned to ns-b and then to ns-c, and to ns-d from ns-a
+func newLineage() []client.Object {
+	return []client.Object{
+		&hasApplicationAPI.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "ns-a"},
+		},
+		&hasApplicationAPI.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "ns-b", Labels: clonedLabels("billing", "ns-a", "billing-app")},
+		},
+		&hasApplicationAPI.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "ns-c", Labels: clonedLabels("billing", "ns-b", "billing-app")},
+		},
+		&hasApplicationAPI.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "ns-d", Labels: clonedLabels("billing-dev", "ns-a", "billing-app")},
+		},
+		&hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "ns-c", Labels: clonedLabels("billing", "ns-b", "billing-app")},
+			Spec:       hasApplicationAPI.ComponentSpec{ComponentName: "c1", Application: "billing-app"},
+		},
+		&appstudioredhatcomv1beta1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "ns-c"},
+			Spec: appstudioredhatcomv1beta1.ApplicationCloneSpec{
+				Source: appstudioredhatcomv1beta1.ApplicationSource{
+					Application: &appstudioredhatcomv1beta1.ApplicationReference{Namespace: "ns-b", Name: "billing-app"},
+				},
+			},
+			Status: appstudioredhatcomv1beta1.ApplicationCloneStatus{
+				Conditions: []metav1.Condition{{
+					Type:    appstudioredhatcomv1beta1.ReadyCondition,
+					Status:  metav1.ConditionTrue,
+					Reason:  appstudioredhatcomv1beta1.ClonedReason,
+					Message: "The Application was cloned",
+				}},
+				Resources: []appstudioredhatcomv1beta1.Resource{
+					{Kind: "Application", Name: "billing-app"},
+					{Kind: "Component", Name: "c2"},
+				},
+				LastAttemptTime: &synced,
+				LastSuccessTime: &synced,
+			},
+		},
+		&appstudioredhatcomv1beta1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-dev", Namespace: "ns-d"},
+			Spec: appstudioredhatcomv1beta1.ApplicationCloneSpec{
+				Source: appstudioredhatcomv1beta1.ApplicationSource{
+					Bundle: &appstudioredhatcomv1beta1.BundleReference{
+						Application: "billing-app",
+						OCI:         &appstudioredhatcomv1beta1.OCIArtifact{Reference: "quay.io/foo/billing:v1"},
+					},
+				},
+				GitOps: &appstudioredhatcomv1beta1.GitOpsTarget{URL: "https://github.com/foo/gitops"},
+			},
+			Status: appstudioredhatcomv1beta1.ApplicationCloneStatus{
+				Conditions: []metav1.Condition{{
+					Type:    appstudioredhatcomv1beta1.ReadyCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  appstudioredhatcomv1beta1.CloneFailedReason,
+					Message: "error cloning repository: authentication required",
+				}},
+				LastAttemptTime: &synced,
+			},
+		},
+	}
+}
+
+var _ = Describe("kubectl-appclone", func() {
+
+	var c client.Client
+
+	BeforeEach(func() {
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(newLineage()...).Build()
+	})
+
+	It("Should list the ApplicationClones", func() {
+		out := &bytes.Buffer{}
+		Expect(listClones(context.Background(), out, c, "")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`ns-c +billing +ns-b/billing-app +direct +2 +True +2023-06-01T10:00:00Z +The Application was cloned`))
+		Expect(out.String()).To(MatchRegexp(`ns-d +billing-dev +bundle quay.io/foo/billing:v1 +gitops +0 +False \(CloneFailed\) +<none> +error cloning repository`))
+
+		out.Reset()
+		Expect(listClones(context.Background(), out, c, "ns-d")).To(Succeed())
+		Expect(out.String()).NotTo(ContainSubstring("ns-c"))
+	})
+
+	It("Should show the status of the cloned resources", func() {
+		out := &bytes.Buffer{}
+		Expect(printStatus(context.Background(), out, c, "ns-c", "billing")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`Source: +ns-b/billing-app`))
+		Expect(out.String()).To(MatchRegexp(`Ready: +True\n`))
+		Expect(out.String()).To(MatchRegexp(`Last sync: +2023-06-01T10:00:00Z`))
+		Expect(out.String()).To(MatchRegexp(`Application +billing-app +cloned`))
+		// c1 isn't in the status yet, but is labeled by the clone
+		Expect(out.String()).To(MatchRegexp(`Component +c1 +cloned`))
+		Expect(out.String()).To(MatchRegexp(`Component +c2 +missing`))
+
+		out.Reset()
+		Expect(printStatus(context.Background(), out, c, "ns-d", "billing-dev")).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`Repository: +https://github.com/foo/gitops`))
+		Expect(out.String()).To(MatchRegexp(`Ready: +False \(CloneFailed\)`))
+		Expect(out.String()).To(MatchRegexp(`Message: +error cloning repository`))
+		Expect(out.String()).To(MatchRegexp(`Last sync: +<none>`))
+
+		Expect(printStatus(context.Background(), out, c, "ns-d", "nope")).To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("Should show the lineage of an Application", func() {
+		out := &bytes.Buffer{}
+		Expect(printLineage(context.Background(), out, c, "ns-c", "billing-app")).To(Succeed())
+		Expect(out.String()).To(HavePrefix("billing-app in ns-a -> ns-b -> ns-c\n"))
+		Expect(out.String()).To(ContainSubstring("billing-app in ns-a\n"))
+		Expect(out.String()).To(ContainSubstring("├── billing-app in ns-b (ApplicationClone billing)\n"))
+		Expect(out.String()).To(ContainSubstring("│   └── billing-app in ns-c (ApplicationClone billing) *\n"))
+		Expect(out.String()).To(ContainSubstring("└── billing-app in ns-d (ApplicationClone billing-dev)\n"))
+
+		out.Reset()
+		Expect(printLineage(context.Background(), out, c, "ns-c", "component/c1")).To(Succeed())
+		Expect(out.String()).To(HavePrefix("Component c1 was cloned by ApplicationClone billing from billing-app in ns-b\n"))
+		Expect(out.String()).To(ContainSubstring("billing-app in ns-a -> ns-b -> ns-c\n"))
+
+		Expect(printLineage(context.Background(), out, c, "ns-c", "secret/foo")).To(MatchError(ContainSubstring("unsupported kind")))
+		Expect(printLineage(context.Background(), out, c, "ns-e", "billing-app")).To(MatchError(ContainSubstring("not found")))
+	})
+
+	It("Should request a resync", func() {
+		now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+		Expect(resync(context.Background(), c, "ns-c", "billing", now)).To(Succeed())
+
+		applicationClone := &appstudioredhatcomv1beta1.ApplicationClone{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "ns-c", Name: "billing"}, applicationClone)).To(Succeed())
+		Expect(applicationClone.Annotations).To(HaveKeyWithValue(resyncAnnotation, "2023-06-01T10:00:00Z"))
+	})
+
+	It("Should reject unknown commands", func() {
+		Expect(run(context.Background(), "destroy", nil, &bytes.Buffer{})).To(MatchError(ContainSubstring("unknown command")))
+		Expect(run(context.Background(), "status", nil, &bytes.Buffer{})).To(MatchError("status takes exactly one name"))
+	})
+})
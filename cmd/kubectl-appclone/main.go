@@ -0,0 +1,55 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-appclone is a kubectl plugin to inspect ApplicationClones and the lineage of cloned Applications.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+)
+
+const usage = `kubectl appclone inspects ApplicationClones and the lineage of cloned Applications.
+
+Usage:
+  kubectl appclone list [-A] [-n namespace]
+      Lists the ApplicationClones of the namespace, or of all namespaces.
+  kubectl appclone status NAME [-n namespace]
+      Shows the status of an ApplicationClone and of each of its resources.
+  kubectl appclone lineage [application/|component/]NAME [-n namespace]
+      Shows where an Application, or the Application of a Component, was cloned from and into.
+  kubectl appclone resync NAME [-n namespace]
+      Makes the controller reconcile an ApplicationClone again.
+
+All commands accept --kubeconfig. The namespace defaults to the namespace of the current context.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	err := run(context.Background(), os.Args[1], os.Args[2:], os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
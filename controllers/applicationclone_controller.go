@@ -18,45 +18,79 @@ package controllers
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
+	"path"
+	"reflect"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
-	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
-
-	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
 )
 
 // ApplicationCloneReconciler reconciles a ApplicationClone object
 type ApplicationCloneReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Build holds the cluster-wide defaults of the build settings of the Components cloned from source
+	Build *appstudioredhatcomv1alpha1.BuildSettings
+
+	// Unstructured clones the resources of Applications read from a cluster as unstructured objects, keeping the
+	// fields the compiled API types lack
+	Unstructured bool
+
+	// DiscoverVersions reads and writes the unstructured objects in the versions preferred by the source cluster
+	DiscoverVersions bool
+
+	// Sanitization holds the rules of the cluster-wide sanitization policy
+	Sanitization []appstudioredhatcomv1alpha1.SanitizationRule
+
+	// RequireGrants refuses to clone an Application into another namespace unless a CloneGrant of its namespace
+	// allows it
+	RequireGrants bool
+
+	// ExtraResourceKinds are the kinds spec.extraResources may select on top of ConfigMaps, Services and Routes
+	ExtraResourceKinds []schema.GroupKind
+
+	// remoteClients are the clients of the clusters Applications are cloned from
+	remoteClients remoteClients
 }
 
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applicationclones,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applicationclones/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applicationclones/finalizers,verbs=update
-
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the ApplicationClone object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.14.1/pkg/reconcile
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=environments,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=snapshotenvironmentbindings,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=snapshots,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups="",resources=configmaps;services,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=clonegrants,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=clonepolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applicationcloneapprovals,verbs=get;list;watch
+
+// Reconcile clones the Application referenced by an ApplicationClone into the
+// namespace of the ApplicationClone, or into the GitOps repository or remote
+// cluster it targets, and reports the result in its status.
 func (r *ApplicationCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = ctrllog.FromContext(ctx)
-
-	// TODO(user): your logic here
-
 	log := ctrllog.FromContext(ctx).WithName("ApplicationClone")
 
 	ctx = ctrllog.IntoContext(ctx, log)
@@ -75,164 +109,229 @@ func (r *ApplicationCloneReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, fmt.Errorf("error reading resource: %w", err)
 	}
 
-	err = r.Client.Create(ctx, &hasApplicationAPI.Application{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      applicationClone.Spec.From.Name,
-			Namespace: applicationClone.Namespace,
-		},
-		Spec: hasApplicationAPI.ApplicationSpec{
-			DisplayName: "appfoo",
-		},
-	})
+	applicationClone.Status.LastAttempt = time.Now().Format(time.RFC3339)
+
+	plan, commit, err := r.clone(ctx, applicationClone)
+	applicationClone.Status.PendingApproval = goerrors.Is(err, clone.ErrPendingApproval)
+	if applicationClone.Status.PendingApproval {
+		// not an error: the clone resumes once approved
+		log.Info("waiting for approval", "application", applicationClone.Spec.From.Name)
+		applicationClone.Status.Error = ""
+		applicationClone.Status.PolicyViolations = nil
+		if err = r.Client.Status().Update(ctx, applicationClone); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error updating status: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+	if err != nil {
+		log.Error(err, "error cloning application", "application", applicationClone.Spec.From.Name)
+		applicationClone.Status.Error = err.Error()
+		applicationClone.Status.PolicyViolations = nil
+		var policyErr *clone.PolicyError
+		if goerrors.As(err, &policyErr) {
+			applicationClone.Status.PolicyViolations = policyErr.Violations
+		}
+		if statusErr := r.Client.Status().Update(ctx, applicationClone); statusErr != nil {
+			log.Error(statusErr, "error updating status")
+		}
+		return ctrl.Result{}, err
+	}
 
+	pendingSecrets, err := clone.PendingSecrets(ctx, r.Client, plan)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("error creating application %v", err)
+		return ctrl.Result{}, err
 	}
 
-	log.Info("successfully created Application CR ", applicationClone.Spec.From.Namespace, applicationClone.Name)
+	applicationClone.Status.Resources = plan.Resources()
+	applicationClone.Status.Commit = commit
+	applicationClone.Status.PendingSecrets = pendingSecrets
+	applicationClone.Status.Sanitized = plan.Sanitized()
+	applicationClone.Status.PolicyViolations = nil
+	applicationClone.Status.Error = ""
+	applicationClone.Status.LastSuccessfulAttempt = applicationClone.Status.LastAttempt
 
-	hasComponentList := &hasApplicationAPI.ComponentList{}
-	err = r.Client.List(ctx, hasComponentList, &client.ListOptions{Namespace: applicationClone.Spec.From.Namespace})
+	err = r.Client.Status().Update(ctx, applicationClone)
 	if err != nil {
-		log.Error(err, "Error listing components")
-		if errors.IsNotFound(err) {
-			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-			// Return and don't requeue
-			return ctrl.Result{}, nil
-		}
-		// Error reading the object - requeue the request.
-		return ctrl.Result{}, fmt.Errorf("error reading resource: %w", err)
+		return ctrl.Result{}, fmt.Errorf("error updating status: %w", err)
 	}
 
-	var componentToBeCloned hasApplicationAPI.ComponentList
+	log.Info("cloned Application", "application", applicationClone.Spec.From.Name, "commit", commit, "pendingSecrets", pendingSecrets)
+
+	return ctrl.Result{}, nil
+}
+
+// clone plans the clone and creates its resources, or commits them in GitOps mode. It returns the plan
+// and, in GitOps mode, the SHA of the commit.
+func (r *ApplicationCloneReconciler) clone(ctx context.Context, applicationClone *appstudioredhatcomv1alpha1.ApplicationClone) (*clone.Plan, string, error) {
+	policyList := &appstudioredhatcomv1alpha1.ClonePolicyList{}
+	err := r.Client.List(ctx, policyList)
+	if err != nil {
+		return nil, "", fmt.Errorf("error listing clonepolicies: %w", err)
+	}
+	// the spec is checked before anything is read from the source
+	err = clone.CheckPolicies(policyList.Items, &applicationClone.Spec)
+	if err != nil {
+		return nil, "", err
+	}
 
-	// Check if the Components belong to the relevant Application
-	for _, c := range hasComponentList.Items {
-		if c.Spec.Application != applicationClone.Spec.From.Name {
-			continue
+	source, from, err := r.source(ctx, applicationClone)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// protected Applications are only cloned once their owners approve, and so are their bundles. The namespace a
+	// bundle was exported from is only known once it is read.
+	requester := appstudioredhatcomv1alpha1.CloneReference{Kind: "ApplicationClone", Namespace: applicationClone.Namespace, Name: applicationClone.Name, Generation: applicationClone.Generation, UID: applicationClone.UID}
+	var approval *appstudioredhatcomv1alpha1.ApprovalRecord
+	if reader, ok := source.(*bundle.Reader); ok {
+		err = clone.CheckBundlePolicies(policyList.Items, reader.SourceNamespace())
+		if err != nil {
+			return nil, "", err
 		}
-		// take everything that's associated with the Application *in*
-		log.Info("found Component", c.Namespace, c.Name)
-		componentToBeCloned.Items = append(componentToBeCloned.Items, c)
-	}
-
-	// Create the Components
-
-	for _, c := range componentToBeCloned.Items {
-		// determine if this is the "source" component or the "image component"
-		for _, sourceComponent := range applicationClone.Spec.ComponentSources {
-			if c.Name == sourceComponent.Name {
-
-				// Create a new Component without specifying the image.
-
-				err = r.Client.Create(ctx, &hasApplicationAPI.Component{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      c.Name,
-						Namespace: applicationClone.Namespace,
-						Annotations: map[string]string{
-							"skip-initial-checks":       "true",
-							"image.redhat.com/generate": `{"visibility": "public"}`,
-						},
-					},
-					Spec: hasApplicationAPI.ComponentSpec{
-						Application:   applicationClone.Spec.From.Name,
-						ComponentName: c.Spec.ComponentName,
-						Source: hasApplicationAPI.ComponentSource{
-							ComponentSourceUnion: hasApplicationAPI.ComponentSourceUnion{
-								GitSource: &hasApplicationAPI.GitSource{
-									URL:           c.Spec.Source.GitSource.URL,
-									Context:       c.Spec.Source.GitSource.Revision,
-									Revision:      c.Spec.Source.GitSource.Revision,
-									DockerfileURL: c.Spec.Source.GitSource.DockerfileURL,
-								},
-							},
-						},
-						Replicas:   c.Spec.Replicas,
-						Resources:  c.Spec.Resources,
-						Env:        c.Spec.Env,
-						TargetPort: c.Spec.TargetPort,
-					},
-				})
-
-				if err != nil {
-					log.Error(err, "error creating Component")
-				} else {
-					log.Info("created component from Source", c.Name, c.Namespace, "Source", c.Spec.Source.GitSource.URL)
-				}
-			} else {
-				// Create a new Component with the image reference.
-
-				r.Client.Create(ctx, &hasApplicationAPI.Component{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      c.Name,
-						Namespace: applicationClone.Namespace,
-						Annotations: map[string]string{
-							"skip-initial-checks": "true",
-						},
-					},
-					Spec: hasApplicationAPI.ComponentSpec{
-						Application:    applicationClone.Spec.From.Name,
-						ComponentName:  c.Spec.ComponentName,
-						Replicas:       c.Spec.Replicas,
-						Resources:      c.Spec.Resources,
-						Env:            c.Spec.Env,
-						TargetPort:     c.Spec.TargetPort,
-						ContainerImage: c.Spec.ContainerImage,
-					},
-				})
-
-				if err != nil {
-					log.Error(err, "error creating Component")
-				}
-				log.Info("created component with Image Reference", c.Namespace, c.Name, "image", c.Spec.ContainerImage)
+		approval, err = clone.CheckBundleApproval(ctx, reader, r.Client, requester, from, time.Now())
+	} else {
+		approval, err = clone.CheckApproval(ctx, source, requester, from, time.Now())
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	applicationClone.Status.Approval = approval
+
+	spec := applicationClone.Spec.DeepCopy()
+	spec.From = from
+
+	planner := &clone.Planner{Source: source, Target: r.Client, Scheme: r.Scheme, Build: r.Build, Sanitization: r.Sanitization, Policies: policyList.Items, Clone: applicationClone.Name}
+	if len(r.ExtraResourceKinds) > 0 {
+		planner.Registry = clone.NewRegistry()
+		for _, gk := range r.ExtraResourceKinds {
+			if err := planner.Registry.AllowExtraResource(gk); err != nil {
+				return nil, "", err
 			}
 		}
 	}
+	// bundles only hold what the compiled types know about
+	if r.Unstructured && from.Bundle == nil {
+		planner.Unstructured = true
+		if c, ok := source.(client.Client); ok && r.DiscoverVersions {
+			planner.RESTMapper = c.RESTMapper()
+		}
+	}
+	plan, err := planner.Plan(ctx, applicationClone.Namespace, spec)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// bundles are handed over by their owners, and Applications can always be cloned within their namespace
+	if r.RequireGrants && from.Bundle == nil && (from.Namespace != applicationClone.Namespace || from.ClusterRef != nil) {
+		err = clone.CheckGrants(ctx, source, applicationClone.Namespace, spec.ServiceAccountName, from, plan.Objects, plan.Placeholder)
+		if err != nil {
+			return nil, "", err
+		}
+	}
 
-	// Setup the Integration Tests
-	testsList := &integrationtestapi.IntegrationTestScenarioList{}
+	// In GitOps mode, the cloned resources are committed instead of created
+	if spec.GitOps != nil {
+		commit, err := r.commit(ctx, applicationClone, from, plan)
+		return plan, commit, err
+	}
 
-	err = r.Client.List(ctx, testsList, &client.ListOptions{Namespace: applicationClone.Spec.From.Namespace})
+	applier := &clone.Applier{Client: r.Client}
+	return plan, "", applier.Apply(ctx, plan)
+}
+
+// commit commits the manifests of the planned objects to the GitOps repository, returning the SHA of the commit.
+func (r *ApplicationCloneReconciler) commit(ctx context.Context, applicationClone *appstudioredhatcomv1alpha1.ApplicationClone, from appstudioredhatcomv1alpha1.From, plan *clone.Plan) (string, error) {
+	gitOps := applicationClone.Spec.GitOps
+	err := validateGitOpsTarget(gitOps)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-			// Return and don't requeue
-			return ctrl.Result{}, nil
-		}
-		// Error reading the object - requeue the request.
-		return ctrl.Result{}, fmt.Errorf("error reading resource: %w", err)
+		return "", err
 	}
 
-	for _, integrationTest := range testsList.Items {
-		if integrationTest.Spec.Application == applicationClone.Name {
-			err = r.Client.Create(ctx, &integrationtestapi.IntegrationTestScenario{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      integrationTest.Name,
-					Namespace: applicationClone.Namespace,
-				},
-				Spec: integrationtestapi.IntegrationTestScenarioSpec{
-					Application: integrationTest.Spec.Application,
-					ResolverRef: integrationTest.Spec.ResolverRef,
-					Params:      integrationTest.Spec.Params,
-					Environment: integrationTest.Spec.Environment,
-					Contexts:    integrationTest.Spec.Contexts,
-				},
-			})
-			if err != nil {
-				log.Error(err, "error creating integrationtestscenario", "application", applicationClone, "test", integrationTest)
-				return ctrl.Result{}, nil
-			}
-		}
+	err = checkGitOpsSecrets(plan)
+	if err != nil {
+		return "", err
 	}
 
-	return ctrl.Result{}, nil
+	// ApplicationClones of a namespace may share a repository, each owning its directory
+	dir := gitOps.Path
+	if dir == "" {
+		dir = path.Join(applicationClone.Namespace, applicationClone.Name)
+	}
+
+	auth, err := gitAuth(ctx, r.Client, applicationClone.Namespace, gitOps.CredentialsSecret)
+	if err != nil {
+		return "", err
+	}
+
+	message := fmt.Sprintf("Clone %s/%s into %s\n\nRendered from ApplicationClone %s/%s.", from.Namespace, from.Name, applicationClone.Namespace, applicationClone.Namespace, applicationClone.Name)
+	return commitManifests(ctx, gitOps, dir, auth, plan.Objects, message)
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager. Besides spec changes, a change of the
+// annotations of an ApplicationClone triggers a resync, and so does a change of its placeholder Secrets or of
+// the ApplicationCloneApprovals approving it. The manager should restrict its Secret cache with
+// RestrictSecretCache. Status changes don't: every attempt updates the status, so
+// reacting to them would clone the Application, and fetch and push the repository in GitOps mode, in a loop.
 func (r *ApplicationCloneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&appstudioredhatcomv1alpha1.ApplicationClone{}).
+		For(&appstudioredhatcomv1alpha1.ApplicationClone{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, userAnnotationChangedPredicate))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(placeholderSecretClone)).
+		Watches(&appstudioredhatcomv1alpha1.ApplicationCloneApproval{}, handler.EnqueueRequestsFromMapFunc(approvedClone)).
 		Complete(r)
 }
+
+// RestrictSecretCache limits the Secrets the cache of the manager holds to the placeholder Secrets, which are the
+// only ones the ApplicationCloneReconciler watches, and has the client read the other Secrets from the API server,
+// so that the Secrets of the whole cluster aren't cached.
+func RestrictSecretCache(options *ctrl.Options) {
+	if options.Cache.ByObject == nil {
+		options.Cache.ByObject = map[client.Object]cache.ByObject{}
+	}
+	options.Cache.ByObject[&corev1.Secret{}] = cache.ByObject{Label: labels.SelectorFromSet(labels.Set{clone.PlaceholderLabel: "true"})}
+
+	if options.Client.Cache == nil {
+		options.Client.Cache = &client.CacheOptions{}
+	}
+	options.Client.Cache.DisableFor = append(options.Client.Cache.DisableFor, &corev1.Secret{})
+}
+
+// userAnnotationChangedPredicate passes the updates changing the annotations of an ApplicationClone, except for
+// the annotation the conversion from v1beta1 keeps the conditions in: it changes along with the status.
+var userAnnotationChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		if e.ObjectOld == nil || e.ObjectNew == nil {
+			return false
+		}
+		return !reflect.DeepEqual(userAnnotations(e.ObjectOld), userAnnotations(e.ObjectNew))
+	},
+}
+
+// userAnnotations returns the annotations of obj without appstudioredhatcomv1alpha1.ConversionAnnotation
+func userAnnotations(obj client.Object) map[string]string {
+	annotations := map[string]string{}
+	for key, value := range obj.GetAnnotations() {
+		if key != appstudioredhatcomv1alpha1.ConversionAnnotation {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// approvedClone returns the ApplicationClone the ApplicationCloneApproval approves, so that it resumes once approved
+func approvedClone(ctx context.Context, obj client.Object) []reconcile.Request {
+	approval, ok := obj.(*appstudioredhatcomv1alpha1.ApplicationCloneApproval)
+	if !ok || (approval.Spec.Clone.Kind != "" && approval.Spec.Clone.Kind != "ApplicationClone") {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: approval.Spec.Clone.Namespace, Name: approval.Spec.Clone.Name}}}
+}
+
+// placeholderSecretClone returns the ApplicationClone that created the placeholder Secret, so that its status
+// follows the Secret being filled in.
+func placeholderSecretClone(ctx context.Context, obj client.Object) []reconcile.Request {
+	name := obj.GetLabels()[clone.CloneLabel]
+	if name == "" || obj.GetAnnotations()[clone.PlaceholderAnnotation] == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: name}}}
+}
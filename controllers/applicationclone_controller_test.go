@@ -0,0 +1,67 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+)
+
+// resyncAnnotation is the annotation kubectl appclone resync sets
+const resyncAnnotation = "clone.appstudio.redhat.com/resync"
+
+var _ = Describe("ApplicationClone watches", func() {
+
+	update := func(old, new map[string]string) event.UpdateEvent {
+		return event.UpdateEvent{
+			ObjectOld: &appstudioredhatcomv1alpha1.ApplicationClone{ObjectMeta: metav1.ObjectMeta{Name: "billing", Annotations: old}},
+			ObjectNew: &appstudioredhatcomv1alpha1.ApplicationClone{ObjectMeta: metav1.ObjectMeta{Name: "billing", Annotations: new}},
+		}
+	}
+
+	It("Should resync when an annotation changes", func() {
+		Expect(userAnnotationChangedPredicate.Update(update(nil, map[string]string{resyncAnnotation: "1"}))).To(BeTrue())
+	})
+
+	It("Should not resync when only the conditions kept by the conversion from v1beta1 change", func() {
+		Expect(userAnnotationChangedPredicate.Update(update(
+			map[string]string{resyncAnnotation: "1", appstudioredhatcomv1alpha1.ConversionAnnotation: `{"conditions":[]}`},
+			map[string]string{resyncAnnotation: "1", appstudioredhatcomv1alpha1.ConversionAnnotation: `{"conditions":[{"type":"Ready"}]}`},
+		))).To(BeFalse())
+	})
+
+	It("Should only cache placeholder Secrets", func() {
+		options := ctrl.Options{}
+		RestrictSecretCache(&options)
+		Expect(options.Client.Cache.DisableFor).To(ConsistOf(&corev1.Secret{}))
+		for obj, byObject := range options.Cache.ByObject {
+			Expect(obj).To(Equal(&corev1.Secret{}))
+			Expect(byObject.Label.Matches(labels.Set{clone.PlaceholderLabel: "true"})).To(BeTrue())
+			Expect(byObject.Label.Matches(labels.Set{clone.CloneLabel: "billing"})).To(BeFalse())
+		}
+		Expect(options.Cache.ByObject).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,135 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	appstudioredhatcomv1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+)
+
+// ApplicationCloneValidator rejects the ApplicationClones whose spec is invalid or violates the ClonePolicies of
+// the cluster, and the ones naming a ServiceAccount the user may not clone on behalf of. What depends on what is
+// cloned, e.g. the names of the cloned Environments, is only checked by the reconciler.
+type ApplicationCloneValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &ApplicationCloneValidator{}
+
+// ValidateCreate checks the policies and the ServiceAccount on creation
+func (v *ApplicationCloneValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	err := v.validate(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+	return nil, v.checkServiceAccount(ctx, nil, obj)
+}
+
+// ValidateUpdate checks the policies on update, and the ServiceAccount when the spec changes
+func (v *ApplicationCloneValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	err := v.validate(ctx, newObj)
+	if err != nil {
+		return nil, err
+	}
+	return nil, v.checkServiceAccount(ctx, oldObj, newObj)
+}
+
+// ValidateDelete lets ApplicationClones be deleted
+func (v *ApplicationCloneValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks the spec of the v1beta1 ApplicationClone against the policies
+func (v *ApplicationCloneValidator) validate(ctx context.Context, obj runtime.Object) error {
+	hub, ok := obj.(*appstudioredhatcomv1beta1.ApplicationClone)
+	if !ok {
+		return fmt.Errorf("expected an ApplicationClone, got %T", obj)
+	}
+	// the policies are checked against the spec the controller reads
+	applicationClone := &appstudioredhatcomv1alpha1.ApplicationClone{}
+	err := applicationClone.ConvertFrom(hub)
+	if err != nil {
+		return err
+	}
+
+	err = clone.ValidateEnvironmentCloning(applicationClone.Spec.Environments)
+	if err != nil {
+		return err
+	}
+
+	if applicationClone.Spec.GitOps != nil {
+		err = validateGitOpsTarget(applicationClone.Spec.GitOps)
+		if err != nil {
+			return err
+		}
+	}
+
+	policyList := &appstudioredhatcomv1alpha1.ClonePolicyList{}
+	err = v.Client.List(ctx, policyList)
+	if err != nil {
+		return fmt.Errorf("error listing clonepolicies: %w", err)
+	}
+	return clone.CheckPolicies(policyList.Items, &applicationClone.Spec)
+}
+
+// checkServiceAccount checks that the user of the admission request may clone on behalf of the ServiceAccount the
+// ApplicationClone names, as CloneGrants may be restricted to it: the user must be the ServiceAccount or be allowed
+// to impersonate it. Updates that keep the spec, e.g. resyncs, aren't checked.
+func (v *ApplicationCloneValidator) checkServiceAccount(ctx context.Context, oldObj, obj runtime.Object) error {
+	applicationClone, ok := obj.(*appstudioredhatcomv1beta1.ApplicationClone)
+	if !ok {
+		return fmt.Errorf("expected an ApplicationClone, got %T", obj)
+	}
+	name := applicationClone.Spec.ServiceAccountName
+	if name == "" {
+		return nil
+	}
+	if old, ok := oldObj.(*appstudioredhatcomv1beta1.ApplicationClone); ok && equality.Semantic.DeepEqual(old.Spec, applicationClone.Spec) {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if req.UserInfo.Username == fmt.Sprintf("system:serviceaccount:%s:%s", applicationClone.Namespace, name) {
+		return nil
+	}
+	allowed, err := reviewAccess(ctx, v.Client, req.UserInfo, &authorizationv1.ResourceAttributes{
+		Namespace: applicationClone.Namespace,
+		Verb:      "impersonate",
+		Resource:  "serviceaccounts",
+		Name:      name,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%s may not clone on behalf of serviceaccount %s, as they aren't allowed to impersonate it", req.UserInfo.Username, name)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	appstudioredhatcomv1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+var _ = Describe("Clone policies", func() {
+
+	var testScheme *runtime.Scheme
+	var policy *appstudioredhatcomv1alpha1.ClonePolicy
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		policy = &appstudioredhatcomv1alpha1.ClonePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-prod"},
+			Spec:       appstudioredhatcomv1alpha1.ClonePolicySpec{BlockedSourceNamespaces: []string{"prod-*"}},
+		}
+	})
+
+	It("Should reject the ApplicationClones violating them", func() {
+		validator := &ApplicationCloneValidator{Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(policy).Build()}
+		applicationClone := &appstudioredhatcomv1beta1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+			Spec: appstudioredhatcomv1beta1.ApplicationCloneSpec{
+				Source: appstudioredhatcomv1beta1.ApplicationSource{
+					Application: &appstudioredhatcomv1beta1.ApplicationReference{Namespace: "prod-billing", Name: "billing-app"},
+				},
+			},
+		}
+		_, err := validator.ValidateCreate(context.Background(), applicationClone)
+		Expect(err).To(MatchError(ContainSubstring("no-prod: Applications can't be cloned from namespace prod-billing")))
+
+		updated := applicationClone.DeepCopy()
+		updated.Spec.Source.Application.Namespace = "staging-billing"
+		_, err = validator.ValidateUpdate(context.Background(), applicationClone, updated)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Should reject invalid Environment names", func() {
+		validator := &ApplicationCloneValidator{Client: fake.NewClientBuilder().WithScheme(testScheme).Build()}
+		_, err := validator.ValidateCreate(context.Background(), &appstudioredhatcomv1beta1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+			Spec: appstudioredhatcomv1beta1.ApplicationCloneSpec{
+				Source: appstudioredhatcomv1beta1.ApplicationSource{
+					Application: &appstudioredhatcomv1beta1.ApplicationReference{Namespace: "foo", Name: "billing-app"},
+				},
+				Environments: &appstudioredhatcomv1beta1.EnvironmentCloning{Clone: true, NamePrefix: "Clone_"},
+			},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`invalid environment name prefix "Clone_"`)))
+	})
+
+	It("Should reject GitOps targets outside of a remote repository", func() {
+		validator := &ApplicationCloneValidator{Client: fake.NewClientBuilder().WithScheme(testScheme).Build()}
+		_, err := validator.ValidateCreate(context.Background(), &appstudioredhatcomv1beta1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+			Spec: appstudioredhatcomv1beta1.ApplicationCloneSpec{
+				Source: appstudioredhatcomv1beta1.ApplicationSource{
+					Application: &appstudioredhatcomv1beta1.ApplicationReference{Namespace: "foo", Name: "billing-app"},
+				},
+				GitOps: &appstudioredhatcomv1beta1.GitOpsTarget{URL: "https://github.com/org/gitops.git", Path: "../other"},
+			},
+		})
+		Expect(err).To(MatchError(ContainSubstring(`gitOps.path must be a clean relative path`)))
+	})
+
+	It("Should report the violations in the status", func() {
+		applicationClone := &appstudioredhatcomv1alpha1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+				From: appstudioredhatcomv1alpha1.From{Namespace: "prod-billing", Name: "billing-app"},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(testScheme).
+			WithObjects(policy, applicationClone).
+			WithStatusSubresource(applicationClone).
+			Build()
+
+		reconciler := &ApplicationCloneReconciler{Client: c, Scheme: testScheme}
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "bar", Name: "billing"}})
+		Expect(err).To(HaveOccurred())
+
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationClone), applicationClone)).To(Succeed())
+		Expect(applicationClone.Status.Error).To(ContainSubstring("violates the ClonePolicies of the cluster"))
+		Expect(applicationClone.Status.PolicyViolations).To(Equal([]appstudioredhatcomv1alpha1.PolicyViolation{
+			{Policy: "no-prod", Message: "Applications can't be cloned from namespace prod-billing"},
+		}))
+	})
+})
+
+var _ = Describe("ApplicationClone ServiceAccounts", func() {
+
+	It("Should only admit the users allowed to clone on behalf of the ServiceAccount", func() {
+		testScheme := runtime.NewScheme()
+		Expect(authorizationv1.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		var reviews []authorizationv1.SubjectAccessReviewSpec
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review := obj.(*authorizationv1.SubjectAccessReview)
+				reviews = append(reviews, review.Spec)
+				review.Status.Allowed = review.Spec.User == "alice"
+				return nil
+			},
+		}).Build()
+		validator := &ApplicationCloneValidator{Client: c}
+		applicationClone := &appstudioredhatcomv1beta1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+			Spec: appstudioredhatcomv1beta1.ApplicationCloneSpec{
+				Source: appstudioredhatcomv1beta1.ApplicationSource{
+					Application: &appstudioredhatcomv1beta1.ApplicationReference{Namespace: "foo", Name: "billing-app"},
+				},
+				ServiceAccountName: "clone-bot",
+			},
+		}
+		request := func(user string) context.Context {
+			return admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: user},
+			}})
+		}
+
+		_, err := validator.ValidateCreate(request("alice"), applicationClone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reviews).To(Equal([]authorizationv1.SubjectAccessReviewSpec{{
+			User:  "alice",
+			Extra: map[string]authorizationv1.ExtraValue{},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: "bar", Verb: "impersonate", Resource: "serviceaccounts", Name: "clone-bot",
+			},
+		}}))
+
+		// the ServiceAccount itself needs no review
+		_, err = validator.ValidateCreate(request("system:serviceaccount:bar:clone-bot"), applicationClone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reviews).To(HaveLen(1))
+
+		_, err = validator.ValidateCreate(request("mallory"), applicationClone)
+		Expect(err).To(MatchError("mallory may not clone on behalf of serviceaccount clone-bot, as they aren't allowed to impersonate it"))
+
+		// changing the source is checked again, a resync isn't
+		updated := applicationClone.DeepCopy()
+		updated.Spec.Source.Application.Name = "payroll-app"
+		_, err = validator.ValidateUpdate(request("mallory"), applicationClone, updated)
+		Expect(err).To(HaveOccurred())
+		updated = applicationClone.DeepCopy()
+		updated.Annotations = map[string]string{resyncAnnotation: "2023-06-01T10:00:00Z"}
+		_, err = validator.ValidateUpdate(request("mallory"), applicationClone, updated)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
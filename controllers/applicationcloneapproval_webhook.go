@@ -0,0 +1,107 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+// ApplicationCloneApprovalValidator only admits the ApplicationCloneApprovals of the users allowed to update the
+// approved Application, as checked by a SubjectAccessReview. Being allowed to create approvals in the namespace
+// isn't enough.
+type ApplicationCloneApprovalValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &ApplicationCloneApprovalValidator{}
+
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// ValidateCreate checks the user creating the approval
+func (v *ApplicationCloneApprovalValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(ctx, obj)
+}
+
+// ValidateUpdate checks the user updating the approval, who becomes its approver
+func (v *ApplicationCloneApprovalValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(ctx, newObj)
+}
+
+// ValidateDelete lets approvals be deleted
+func (v *ApplicationCloneApprovalValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate checks that the user of the admission request may update the approved Application
+func (v *ApplicationCloneApprovalValidator) validate(ctx context.Context, obj runtime.Object) error {
+	approval, ok := obj.(*appstudioredhatcomv1alpha1.ApplicationCloneApproval)
+	if !ok {
+		return fmt.Errorf("expected an ApplicationCloneApproval, got %T", obj)
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	allowed, err := reviewAccess(ctx, v.Client, req.UserInfo, &authorizationv1.ResourceAttributes{
+		Namespace: approval.Namespace,
+		Verb:      "update",
+		Group:     hasApplicationAPI.GroupVersion.Group,
+		Resource:  "applications",
+		Name:      approval.Spec.Application,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%s may not approve clones of application %s, as they aren't allowed to update it", req.UserInfo.Username, approval.Spec.Application)
+	}
+	return nil
+}
+
+// reviewAccess tells whether the user of an admission request may access the resource, as checked by a
+// SubjectAccessReview
+func reviewAccess(ctx context.Context, c client.Client, user authenticationv1.UserInfo, attributes *authorizationv1.ResourceAttributes) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               user.Username,
+			UID:                user.UID,
+			Groups:             user.Groups,
+			Extra:              extra,
+			ResourceAttributes: attributes,
+		},
+	}
+	err := c.Create(ctx, review)
+	if err != nil {
+		return false, fmt.Errorf("error reviewing the access of %s: %w", user.Username, err)
+	}
+	return review.Status.Allowed, nil
+}
@@ -0,0 +1,481 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	goerrors "errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+const (
+	// defaultCopyImage is the image of the Job copying bundles to PersistentVolumeClaims
+	defaultCopyImage = "registry.access.redhat.com/ubi9/ubi-minimal:latest"
+
+	// exportAnnotation is set on the ConfigMaps and Secrets holding bundles to the name of the ApplicationExport
+	// that wrote them. The others aren't overwritten.
+	exportAnnotation = "clone.appstudio.redhat.com/export"
+)
+
+// ApplicationExportReconciler reconciles a ApplicationExport object
+type ApplicationExportReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// CopyImage is the image of the Job copying bundles to PersistentVolumeClaims. Defaults to a UBI minimal image.
+	CopyImage string
+
+	// RequireGrants refuses to export an Application into another namespace unless a CloneGrant of its namespace
+	// allows it to be cloned there
+	RequireGrants bool
+
+	// remoteClients are the clients of the clusters Applications are exported from
+	remoteClients remoteClients
+}
+
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applicationexports,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applicationexports/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applicationexports/finalizers,verbs=update
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=applications;components,verbs=get;list;watch
+//+kubebuilder:rbac:groups=appstudio.redhat.com,resources=integrationtestscenarios,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+
+// Reconcile writes the bundle of the exported Application to the ApplicationExport's target.
+func (r *ApplicationExportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrllog.FromContext(ctx).WithName("ApplicationExport")
+
+	ctx = ctrllog.IntoContext(ctx, log)
+
+	applicationExport := &appstudioredhatcomv1alpha1.ApplicationExport{}
+
+	err := r.Client.Get(ctx, req.NamespacedName, applicationExport)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, fmt.Errorf("error reading resource: %w", err)
+	}
+
+	applicationExport.Status.LastAttempt = time.Now().Format(time.RFC3339)
+
+	exported, err := r.export(ctx, applicationExport)
+	applicationExport.Status.PendingApproval = goerrors.Is(err, clone.ErrPendingApproval)
+	if applicationExport.Status.PendingApproval {
+		// not an error: the export resumes once approved
+		log.Info("waiting for approval", "application", applicationExport.Spec.From.Name)
+		applicationExport.Status.Error = ""
+		applicationExport.Status.PolicyViolations = nil
+		if err = r.Client.Status().Update(ctx, applicationExport); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error updating status: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+	if err != nil {
+		log.Error(err, "error exporting application", "application", applicationExport.Spec.From.Name)
+		applicationExport.Status.Error = err.Error()
+		applicationExport.Status.PolicyViolations = nil
+		var policyErr *clone.PolicyError
+		if goerrors.As(err, &policyErr) {
+			applicationExport.Status.PolicyViolations = policyErr.Violations
+		}
+		if statusErr := r.Client.Status().Update(ctx, applicationExport); statusErr != nil {
+			log.Error(statusErr, "error updating status")
+		}
+		return ctrl.Result{}, err
+	}
+
+	applicationExport.Status.Resources = exported.resources
+	applicationExport.Status.BundleVersion = bundle.APIVersion
+	applicationExport.Status.ConfigMap = exported.configMap
+	applicationExport.Status.Digest = exported.digest
+	applicationExport.Status.Approval = exported.approval
+	applicationExport.Status.PolicyViolations = nil
+	applicationExport.Status.Error = ""
+	applicationExport.Status.LastSuccessfulAttempt = applicationExport.Status.LastAttempt
+
+	err = r.Client.Status().Update(ctx, applicationExport)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("error updating status: %w", err)
+	}
+
+	log.Info("exported Application", "application", applicationExport.Spec.From.Name, "configmap", exported.configMap, "digest", exported.digest)
+
+	return ctrl.Result{}, nil
+}
+
+// exportResult describes where a bundle was written to
+type exportResult struct {
+	// configMap holding the bundle, if any
+	configMap string
+	// digest of the OCI artifact holding the bundle, if any
+	digest    string
+	resources []appstudioredhatcomv1alpha1.Resource
+	// approval the protected Application was exported under, if any
+	approval *appstudioredhatcomv1alpha1.ApprovalRecord
+}
+
+// export writes the bundle to its target. An export puts the resources of the Application in the hands of whoever
+// can read the target, so it passes the same checks as a clone into the namespace of the ApplicationExport: the
+// ClonePolicies of the cluster, the approval of protected Applications and, when required, CloneGrants.
+func (r *ApplicationExportReconciler) export(ctx context.Context, applicationExport *appstudioredhatcomv1alpha1.ApplicationExport) (exportResult, error) {
+	target := applicationExport.Spec.To
+	targets := 0
+	for _, set := range []bool{target.ConfigMap != "", target.PersistentVolumeClaim != nil, target.OCI != nil} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return exportResult{}, fmt.Errorf("exactly one of configMap, persistentVolumeClaim and oci must be set")
+	}
+
+	if applicationExport.Spec.From.Bundle != nil {
+		return exportResult{}, fmt.Errorf("from.bundle is not supported for exports")
+	}
+	if applicationExport.Spec.From.Namespace == "" || applicationExport.Spec.From.Name == "" {
+		return exportResult{}, fmt.Errorf("from.namespace and from.name are required")
+	}
+
+	from := applicationExport.Spec.From
+	otherNamespace := from.Namespace != applicationExport.Namespace || from.ClusterRef != nil
+	if applicationExport.Spec.Secrets == appstudioredhatcomv1alpha1.SecretExportInclude && otherNamespace {
+		return exportResult{}, fmt.Errorf("secrets: Include only exports the Secrets of the namespace of the ApplicationExport, use Redacted or None")
+	}
+	// what is pushed to a registry is only protected by the credentials of the registry, not by the RBAC of the cluster
+	if applicationExport.Spec.Secrets == appstudioredhatcomv1alpha1.SecretExportInclude && target.OCI != nil {
+		return exportResult{}, fmt.Errorf("secrets: Include can't be pushed to an OCI registry, use Redacted or None")
+	}
+	// ConfigMaps are commonly readable by many more users than Secrets
+	if applicationExport.Spec.Secrets == appstudioredhatcomv1alpha1.SecretExportInclude && target.ConfigMap != "" {
+		return exportResult{}, fmt.Errorf("secrets: Include can't be written to a ConfigMap, use Redacted or None")
+	}
+
+	policyList := &appstudioredhatcomv1alpha1.ClonePolicyList{}
+	err := r.Client.List(ctx, policyList)
+	if err != nil {
+		return exportResult{}, fmt.Errorf("error listing clonepolicies: %w", err)
+	}
+	err = clone.CheckExportPolicies(policyList.Items, &applicationExport.Spec)
+	if err != nil {
+		return exportResult{}, err
+	}
+
+	source, err := r.remoteClients.clientFor(ctx, r.Client, r.Scheme, applicationExport.Namespace, from.ClusterRef)
+	if err != nil {
+		return exportResult{}, err
+	}
+
+	// protected Applications are only exported once their owners approve
+	requester := appstudioredhatcomv1alpha1.CloneReference{Kind: "ApplicationExport", Namespace: applicationExport.Namespace, Name: applicationExport.Name, Generation: applicationExport.Generation, UID: applicationExport.UID}
+	approval, err := clone.CheckApproval(ctx, source, requester, from, time.Now())
+	if err != nil {
+		return exportResult{}, err
+	}
+
+	b, resources, err := buildBundle(ctx, source, from, applicationExport.Spec.IntegrationTests, applicationExport.Spec.Secrets)
+	if err != nil {
+		return exportResult{}, err
+	}
+
+	// Applications can always be exported within their namespace
+	if r.RequireGrants && otherNamespace {
+		err = clone.CheckGrants(ctx, source, applicationExport.Namespace, "", from, b.Objects(), bundle.Redacted)
+		if err != nil {
+			return exportResult{}, err
+		}
+	}
+
+	if target.OCI != nil {
+		opts, err := registryOptions(ctx, r.Client, applicationExport.Namespace, target.OCI.CredentialsSecret)
+		if err != nil {
+			return exportResult{}, err
+		}
+		digest, err := bundle.Push(ctx, target.OCI.Reference, b, opts...)
+		if err != nil {
+			return exportResult{}, err
+		}
+		return exportResult{digest: digest, resources: resources, approval: approval}, nil
+	}
+
+	data, err := b.Marshal()
+	if err != nil {
+		return exportResult{}, fmt.Errorf("error serializing bundle: %w", err)
+	}
+
+	if target.PersistentVolumeClaim != nil {
+		// the bundle is staged for the copy Job in a Secret, as it may hold the values of Secrets
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      applicationExport.Name + "-bundle",
+				Namespace: applicationExport.Namespace,
+			},
+		}
+		_, err = controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+			err := exportedBy(secret, applicationExport)
+			if err != nil {
+				return err
+			}
+			secret.Data = map[string][]byte{bundle.FileName: data}
+			return controllerutil.SetControllerReference(applicationExport, secret, r.Scheme)
+		})
+		if err != nil {
+			return exportResult{}, fmt.Errorf("error staging bundle in secret %s: %w", secret.Name, err)
+		}
+
+		err = r.copyToPersistentVolumeClaim(ctx, applicationExport, secret.Name)
+		if err != nil {
+			return exportResult{}, err
+		}
+		return exportResult{resources: resources, approval: approval}, nil
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      target.ConfigMap,
+			Namespace: applicationExport.Namespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		err := exportedBy(configMap, applicationExport)
+		if err != nil {
+			return err
+		}
+		configMap.Data = map[string]string{bundle.FileName: string(data)}
+		return nil
+	})
+	if err != nil {
+		return exportResult{}, fmt.Errorf("error writing bundle to configmap %s: %w", configMap.Name, err)
+	}
+
+	return exportResult{configMap: configMap.Name, resources: resources, approval: approval}, nil
+}
+
+// exportedBy refuses to overwrite an existing object that doesn't hold a bundle of the ApplicationExport, and
+// records the ApplicationExport in the exportAnnotation of the object otherwise
+func exportedBy(obj client.Object, applicationExport *appstudioredhatcomv1alpha1.ApplicationExport) error {
+	annotations := obj.GetAnnotations()
+	if obj.GetResourceVersion() != "" && annotations[exportAnnotation] != applicationExport.Name {
+		return fmt.Errorf("%s already exists and doesn't hold a bundle of ApplicationExport %s", obj.GetName(), applicationExport.Name)
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[exportAnnotation] = applicationExport.Name
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// copyToPersistentVolumeClaim starts a Job copying the bundle from the Secret to the claim. A Job is created for
+// every generation of the ApplicationExport.
+func (r *ApplicationExportReconciler) copyToPersistentVolumeClaim(ctx context.Context, applicationExport *appstudioredhatcomv1alpha1.ApplicationExport, secret string) error {
+	target := applicationExport.Spec.To.PersistentVolumeClaim
+
+	bundlePath := target.Path
+	if bundlePath == "" {
+		bundlePath = applicationExport.Name + ".yaml"
+	}
+
+	image := r.CopyImage
+	if image == "" {
+		image = defaultCopyImage
+	}
+
+	ttl := int32(3600)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      copyJobName(applicationExport.Name, applicationExport.Generation),
+			Namespace: applicationExport.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					Containers: []corev1.Container{
+						{
+							Name:    "copy",
+							Image:   image,
+							Command: []string{"cp", path.Join("/bundle", bundle.FileName), path.Join("/export", bundlePath)},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "bundle", MountPath: "/bundle", ReadOnly: true},
+								{Name: "export", MountPath: "/export"},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "bundle",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{SecretName: secret},
+							},
+						},
+						{
+							Name: "export",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: target.ClaimName},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := controllerutil.SetControllerReference(applicationExport, job, r.Scheme)
+	if err != nil {
+		return err
+	}
+
+	err = r.Client.Create(ctx, job)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("error creating copy job %s: %w", job.Name, err)
+	}
+
+	return nil
+}
+
+// copyJobName returns the name of the Job copying the bundle of the generation of the ApplicationExport. Long names
+// are truncated and suffixed with a hash of the full name, so that the name of the Pods of the Job stays a valid
+// label value.
+func copyJobName(name string, generation int64) string {
+	jobName := fmt.Sprintf("%s-copy-%d", name, generation)
+	if len(jobName) <= validation.DNS1123LabelMaxLength {
+		return jobName
+	}
+	sum := sha256.Sum256([]byte(jobName))
+	hash := hex.EncodeToString(sum[:])[:8]
+	return strings.TrimRight(jobName[:validation.DNS1123LabelMaxLength-len(hash)-1], "-.") + "-" + hash
+}
+
+// buildBundle reads the Application, its Components, the selected IntegrationTestScenarios and the
+// Secrets referenced by the Components from the source, returning them as a bundle.
+func buildBundle(ctx context.Context, source client.Reader, from appstudioredhatcomv1alpha1.From, selection *appstudioredhatcomv1alpha1.IntegrationTestSelection, secrets appstudioredhatcomv1alpha1.SecretExportPolicy) (*bundle.Bundle, []appstudioredhatcomv1alpha1.Resource, error) {
+	var resources []appstudioredhatcomv1alpha1.Resource
+
+	application := &hasApplicationAPI.Application{}
+	err := source.Get(ctx, types.NamespacedName{Namespace: from.Namespace, Name: from.Name}, application)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading application %s: %w", from.Name, err)
+	}
+
+	b := bundle.New(application)
+	resources = append(resources, appstudioredhatcomv1alpha1.Resource{Kind: "Application", Name: application.Name})
+
+	componentList := &hasApplicationAPI.ComponentList{}
+	err = source.List(ctx, componentList, &client.ListOptions{Namespace: from.Namespace})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing components: %w", err)
+	}
+
+	var components []*hasApplicationAPI.Component
+	for i := range componentList.Items {
+		component := &componentList.Items[i]
+		if component.Spec.Application != from.Name {
+			continue
+		}
+		b.AddComponent(component)
+		resources = append(resources, appstudioredhatcomv1alpha1.Resource{Kind: "Component", Name: component.Name})
+		components = append(components, component)
+	}
+
+	testsList := &integrationtestapi.IntegrationTestScenarioList{}
+	err = source.List(ctx, testsList, &client.ListOptions{Namespace: from.Namespace})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing integrationtestscenarios: %w", err)
+	}
+
+	selectedTests, err := clone.SelectIntegrationTests(selection, from.Name, testsList.Items)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error selecting integration tests: %w", err)
+	}
+	for i := range selectedTests {
+		b.AddIntegrationTestScenario(&selectedTests[i])
+		resources = append(resources, appstudioredhatcomv1alpha1.Resource{Kind: "IntegrationTestScenario", Name: selectedTests[i].Name})
+	}
+
+	if secrets == appstudioredhatcomv1alpha1.SecretExportNone {
+		return b, resources, nil
+	}
+
+	for _, name := range clone.SecretNames(components...) {
+		secret := &corev1.Secret{}
+		err = source.Get(ctx, types.NamespacedName{Namespace: from.Namespace, Name: name}, secret)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				ctrllog.FromContext(ctx).Info("referenced Secret not found, skipping", "namespace", from.Namespace, "secret", name)
+				continue
+			}
+			return nil, nil, fmt.Errorf("error reading secret %s: %w", name, err)
+		}
+		b.AddSecret(secret, secrets != appstudioredhatcomv1alpha1.SecretExportInclude)
+		resources = append(resources, appstudioredhatcomv1alpha1.Resource{Kind: "Secret", Name: secret.Name})
+	}
+
+	return b, resources, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Besides spec changes, a change of the
+// ApplicationCloneApprovals approving an ApplicationExport triggers a resync.
+func (r *ApplicationExportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appstudioredhatcomv1alpha1.ApplicationExport{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&appstudioredhatcomv1alpha1.ApplicationCloneApproval{}, handler.EnqueueRequestsFromMapFunc(approvedExport)).
+		Complete(r)
+}
+
+// approvedExport returns the ApplicationExport the ApplicationCloneApproval approves, so that it resumes once
+// approved
+func approvedExport(ctx context.Context, obj client.Object) []reconcile.Request {
+	approval, ok := obj.(*appstudioredhatcomv1alpha1.ApplicationCloneApproval)
+	if !ok || approval.Spec.Clone.Kind != "ApplicationExport" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: approval.Spec.Clone.Namespace, Name: approval.Spec.Clone.Name}}}
+}
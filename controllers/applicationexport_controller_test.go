@@ -0,0 +1,385 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+func newExportSource() []runtime.Object {
+	return []runtime.Object{
+		&hasApplicationAPI.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "exportapp", Namespace: "export-foo"},
+			Spec:       hasApplicationAPI.ApplicationSpec{DisplayName: "Export"},
+		},
+		&hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "export-c1", Namespace: "export-foo"},
+			Spec: hasApplicationAPI.ComponentSpec{
+				ComponentName:  "export-c1",
+				Application:    "exportapp",
+				Secret:         "git-token",
+				ContainerImage: "quay.io/foo/export-c1",
+				Env: []corev1.EnvVar{{
+					Name:      "API_TOKEN",
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "api-token"}, Key: "token"}},
+				}},
+			},
+		},
+		&hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-c1", Namespace: "export-foo"},
+			Spec: hasApplicationAPI.ComponentSpec{
+				ComponentName: "other-c1",
+				Application:   "otherapp",
+			},
+		},
+		&integrationtestapi.IntegrationTestScenario{
+			ObjectMeta: metav1.ObjectMeta{Name: "export-it1", Namespace: "export-foo"},
+			Spec: integrationtestapi.IntegrationTestScenarioSpec{
+				Application: "exportapp",
+				ResolverRef: integrationtestapi.ResolverRef{
+					Resolver: "git",
+					Params:   []integrationtestapi.ResolverParameter{},
+				},
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-token", Namespace: "export-foo"},
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-token", Namespace: "export-foo"},
+			Data:       map[string][]byte{"token": []byte("s3cr3t")},
+		},
+	}
+}
+
+var _ = Describe("Application bundles", func() {
+
+	It("Should collect the Application's resources", func() {
+		testScheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithRuntimeObjects(newExportSource()...).Build()
+		from := appstudioredhatcomv1alpha1.From{Namespace: "export-foo", Name: "exportapp"}
+
+		b, resources, err := buildBundle(context.Background(), c, from, nil, appstudioredhatcomv1alpha1.SecretExportRedacted)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.Application.Spec.DisplayName).To(Equal("Export"))
+		Expect(b.Components).To(HaveLen(1))
+		Expect(b.IntegrationTestScenarios).To(HaveLen(1))
+		// the Secrets the Components refer to in their environment too
+		Expect(b.Secrets).To(HaveLen(2))
+		Expect(b.Secrets[0].Data).To(HaveKeyWithValue("password", []byte{}))
+		Expect(b.Secrets[1].Name).To(Equal("api-token"))
+		Expect(resources).To(ConsistOf(
+			appstudioredhatcomv1alpha1.Resource{Kind: "Application", Name: "exportapp"},
+			appstudioredhatcomv1alpha1.Resource{Kind: "Component", Name: "export-c1"},
+			appstudioredhatcomv1alpha1.Resource{Kind: "IntegrationTestScenario", Name: "export-it1"},
+			appstudioredhatcomv1alpha1.Resource{Kind: "Secret", Name: "git-token"},
+			appstudioredhatcomv1alpha1.Resource{Kind: "Secret", Name: "api-token"},
+		))
+
+		b, _, err = buildBundle(context.Background(), c, from, &appstudioredhatcomv1alpha1.IntegrationTestSelection{None: true}, appstudioredhatcomv1alpha1.SecretExportNone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.IntegrationTestScenarios).To(BeEmpty())
+		Expect(b.Secrets).To(BeEmpty())
+	})
+})
+
+var _ = Describe("ApplicationExport checks", func() {
+
+	var testScheme *runtime.Scheme
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+	})
+
+	// reconcile exports the exportapp Application into namespace bar with the secrets policy, and returns the
+	// resulting ApplicationExport
+	reconcile := func(c client.Client, reconciler *ApplicationExportReconciler, secrets appstudioredhatcomv1alpha1.SecretExportPolicy) (*appstudioredhatcomv1alpha1.ApplicationExport, ctrl.Result, error) {
+		applicationExport := &appstudioredhatcomv1alpha1.ApplicationExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "exportapp", Namespace: "bar", Generation: 1, UID: "exportapp-uid"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationExportSpec{
+				From:    appstudioredhatcomv1alpha1.From{Namespace: "export-foo", Name: "exportapp"},
+				Secrets: secrets,
+				To:      appstudioredhatcomv1alpha1.ExportTarget{ConfigMap: "exportapp-bundle"},
+			},
+		}
+		Expect(c.Create(context.Background(), applicationExport)).To(Succeed())
+
+		result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(applicationExport)})
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationExport), applicationExport)).To(Succeed())
+		return applicationExport, result, err
+	}
+
+	newClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().WithScheme(testScheme).
+			WithRuntimeObjects(newExportSource()...).
+			WithObjects(objs...).
+			WithStatusSubresource(&appstudioredhatcomv1alpha1.ApplicationExport{}).
+			Build()
+	}
+
+	It("Should only include Secret values of the namespace of the ApplicationExport", func() {
+		c := newClient()
+		applicationExport, _, err := reconcile(c, &ApplicationExportReconciler{Client: c, Scheme: testScheme}, appstudioredhatcomv1alpha1.SecretExportInclude)
+		Expect(err).To(MatchError(ContainSubstring("secrets: Include only exports the Secrets of the namespace of the ApplicationExport")))
+		Expect(applicationExport.Status.Error).NotTo(BeEmpty())
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "exportapp-bundle"}, &corev1.ConfigMap{})).NotTo(Succeed())
+	})
+
+	It("Should not push Secret values to registries", func() {
+		applicationExport := &appstudioredhatcomv1alpha1.ApplicationExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "exportapp", Namespace: "export-foo"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationExportSpec{
+				From:    appstudioredhatcomv1alpha1.From{Namespace: "export-foo", Name: "exportapp"},
+				Secrets: appstudioredhatcomv1alpha1.SecretExportInclude,
+				To:      appstudioredhatcomv1alpha1.ExportTarget{OCI: &appstudioredhatcomv1alpha1.OCIArtifact{Reference: "quay.io/org/templates/exportapp:v1"}},
+			},
+		}
+		c := newClient()
+		_, err := (&ApplicationExportReconciler{Client: c, Scheme: testScheme}).export(context.Background(), applicationExport)
+		Expect(err).To(MatchError(ContainSubstring("secrets: Include can't be pushed to an OCI registry")))
+	})
+
+	It("Should not write Secret values to ConfigMaps", func() {
+		applicationExport := &appstudioredhatcomv1alpha1.ApplicationExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "exportapp", Namespace: "export-foo"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationExportSpec{
+				From:    appstudioredhatcomv1alpha1.From{Namespace: "export-foo", Name: "exportapp"},
+				Secrets: appstudioredhatcomv1alpha1.SecretExportInclude,
+				To:      appstudioredhatcomv1alpha1.ExportTarget{ConfigMap: "exportapp-bundle"},
+			},
+		}
+		c := newClient()
+		_, err := (&ApplicationExportReconciler{Client: c, Scheme: testScheme}).export(context.Background(), applicationExport)
+		Expect(err).To(MatchError(ContainSubstring("secrets: Include can't be written to a ConfigMap")))
+	})
+
+	It("Should stage the bundles of PersistentVolumeClaims in a Secret", func() {
+		applicationExport := &appstudioredhatcomv1alpha1.ApplicationExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "exportapp", Namespace: "export-foo", Generation: 2},
+			Spec: appstudioredhatcomv1alpha1.ApplicationExportSpec{
+				From:    appstudioredhatcomv1alpha1.From{Namespace: "export-foo", Name: "exportapp"},
+				Secrets: appstudioredhatcomv1alpha1.SecretExportInclude,
+				To:      appstudioredhatcomv1alpha1.ExportTarget{PersistentVolumeClaim: &appstudioredhatcomv1alpha1.PersistentVolumeClaimTarget{ClaimName: "archive"}},
+			},
+		}
+		Expect(batchv1.AddToScheme(testScheme)).To(Succeed())
+		c := newClient(applicationExport)
+		exported, err := (&ApplicationExportReconciler{Client: c, Scheme: testScheme}).export(context.Background(), applicationExport)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exported.configMap).To(BeEmpty())
+
+		secret := &corev1.Secret{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "export-foo", Name: "exportapp-bundle"}, secret)).To(Succeed())
+		Expect(secret.Annotations).To(HaveKeyWithValue(exportAnnotation, "exportapp"))
+		b, err := bundle.Unmarshal(secret.Data[bundle.FileName])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b.Secrets[0].Data).To(HaveKeyWithValue("password", []byte("hunter2")))
+
+		job := &batchv1.Job{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "export-foo", Name: "exportapp-copy-2"}, job)).To(Succeed())
+		Expect(job.Spec.Template.Spec.Volumes[0].Secret.SecretName).To(Equal("exportapp-bundle"))
+	})
+
+	It("Should not overwrite ConfigMaps other than its own", func() {
+		c := newClient(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "exportapp-bundle", Namespace: "bar"},
+			Data:       map[string]string{"settings": "keep"},
+		})
+		applicationExport, _, err := reconcile(c, &ApplicationExportReconciler{Client: c, Scheme: testScheme}, appstudioredhatcomv1alpha1.SecretExportRedacted)
+		Expect(err).To(MatchError(ContainSubstring("exportapp-bundle already exists and doesn't hold a bundle of ApplicationExport exportapp")))
+		Expect(applicationExport.Status.ConfigMap).To(BeEmpty())
+		configMap := &corev1.ConfigMap{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "exportapp-bundle"}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(Equal(map[string]string{"settings": "keep"}))
+
+		// once adopted, the ConfigMap is the export's own
+		configMap.Annotations = map[string]string{exportAnnotation: "exportapp"}
+		Expect(c.Update(context.Background(), configMap)).To(Succeed())
+		_, err = (&ApplicationExportReconciler{Client: c, Scheme: testScheme}).Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(applicationExport)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "exportapp-bundle"}, configMap)).To(Succeed())
+		Expect(configMap.Data).To(HaveKey(bundle.FileName))
+	})
+
+	It("Should keep the names of copy Jobs short enough", func() {
+		Expect(copyJobName("exportapp", 3)).To(Equal("exportapp-copy-3"))
+
+		long := strings.Repeat("billing-application-", 10)
+		name := copyJobName(long, 3)
+		Expect(len(name)).To(BeNumerically("<=", 63))
+		Expect(validation.IsDNS1123Label(name)).To(BeEmpty())
+		Expect(name).NotTo(Equal(copyJobName(long, 4)))
+	})
+
+	It("Should apply the ClonePolicies", func() {
+		c := newClient(&appstudioredhatcomv1alpha1.ClonePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-export"},
+			Spec:       appstudioredhatcomv1alpha1.ClonePolicySpec{BlockedSourceNamespaces: []string{"export-*"}},
+		})
+		applicationExport, _, err := reconcile(c, &ApplicationExportReconciler{Client: c, Scheme: testScheme}, appstudioredhatcomv1alpha1.SecretExportRedacted)
+		Expect(err).To(HaveOccurred())
+		Expect(applicationExport.Status.PolicyViolations).To(Equal([]appstudioredhatcomv1alpha1.PolicyViolation{
+			{Policy: "no-export", Message: "Applications can't be exported from namespace export-foo"},
+		}))
+	})
+
+	It("Should require a CloneGrant to export into another namespace", func() {
+		c := newClient()
+		reconciler := &ApplicationExportReconciler{Client: c, Scheme: testScheme, RequireGrants: true}
+		_, _, err := reconcile(c, reconciler, appstudioredhatcomv1alpha1.SecretExportRedacted)
+		Expect(err).To(MatchError("no CloneGrant of namespace export-foo allows cloning application exportapp into namespace bar"))
+
+		Expect(c.Create(context.Background(), &appstudioredhatcomv1alpha1.CloneGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "bar", Namespace: "export-foo"},
+			Spec: appstudioredhatcomv1alpha1.CloneGrantSpec{
+				From:  []appstudioredhatcomv1alpha1.CloneGrantFrom{{Namespace: "bar"}},
+				Kinds: []appstudioredhatcomv1alpha1.GrantedKind{{Group: integrationtestapi.GroupVersion.Group, Kind: "IntegrationTestScenario"}},
+			},
+		})).To(Succeed())
+		_, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "bar", Name: "exportapp"}})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Should wait for the approval of protected Applications", func() {
+		c := newClient()
+		application := &hasApplicationAPI.Application{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "export-foo", Name: "exportapp"}, application)).To(Succeed())
+		application.Annotations = map[string]string{clone.ProtectedAnnotation: "true"}
+		Expect(c.Update(context.Background(), application)).To(Succeed())
+
+		reconciler := &ApplicationExportReconciler{Client: c, Scheme: testScheme}
+		applicationExport, result, err := reconcile(c, reconciler, appstudioredhatcomv1alpha1.SecretExportRedacted)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(applicationExport.Status.PendingApproval).To(BeTrue())
+		Expect(applicationExport.Status.ConfigMap).To(BeEmpty())
+
+		// an approval of a clone of the same name doesn't approve the export
+		approval := &appstudioredhatcomv1alpha1.ApplicationCloneApproval{
+			ObjectMeta: metav1.ObjectMeta{Name: "exportapp-to-bar", Namespace: "export-foo"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+				Application:    "exportapp",
+				Clone:          appstudioredhatcomv1alpha1.CloneReference{Namespace: "bar", Name: "exportapp", Generation: 1, UID: "exportapp-uid"},
+				ExpirationTime: metav1.NewTime(time.Now().Add(time.Hour)),
+				Approver:       "alice",
+			},
+		}
+		Expect(c.Create(context.Background(), approval)).To(Succeed())
+		Expect(approvedExport(context.Background(), approval)).To(BeEmpty())
+		request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(applicationExport)}
+		_, err = reconciler.Reconcile(context.Background(), request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationExport), applicationExport)).To(Succeed())
+		Expect(applicationExport.Status.PendingApproval).To(BeTrue())
+
+		approval.Spec.Clone.Kind = "ApplicationExport"
+		Expect(c.Update(context.Background(), approval)).To(Succeed())
+		Expect(approvedExport(context.Background(), approval)).To(Equal([]ctrl.Request{request}))
+		_, err = reconciler.Reconcile(context.Background(), request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationExport), applicationExport)).To(Succeed())
+		Expect(applicationExport.Status.PendingApproval).To(BeFalse())
+		Expect(applicationExport.Status.ConfigMap).To(Equal("exportapp-bundle"))
+		Expect(applicationExport.Status.Approval.Approver).To(Equal("alice"))
+	})
+})
+
+var _ = Describe("ApplicationExport controller", func() {
+
+	Context("When creating an ApplicationExport", func() {
+		It("Should write the bundle to the ConfigMap", func() {
+			ctx := context.Background()
+			createNamespace(ctx, "export-foo")
+
+			for _, obj := range newExportSource() {
+				Expect(k8sClient.Create(ctx, obj.(client.Object))).To(Succeed())
+			}
+
+			Expect(k8sClient.Create(ctx, &appstudioredhatcomv1alpha1.ApplicationExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "exportapp",
+					Namespace: "export-foo",
+				},
+				Spec: appstudioredhatcomv1alpha1.ApplicationExportSpec{
+					From: appstudioredhatcomv1alpha1.From{
+						Name:      "exportapp",
+						Namespace: "export-foo",
+					},
+					To: appstudioredhatcomv1alpha1.ExportTarget{
+						ConfigMap: "exportapp-bundle",
+					},
+				},
+			})).To(Succeed())
+
+			configMap := &corev1.ConfigMap{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "exportapp-bundle",
+					Namespace: "export-foo",
+				}, configMap)
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+
+			b, err := bundle.Unmarshal([]byte(configMap.Data[bundle.FileName]))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b.Application.Name).To(Equal("exportapp"))
+			Expect(b.Components).To(HaveLen(1))
+			Expect(b.Secrets).To(HaveLen(2))
+			Expect(b.Secrets[0].Annotations).To(HaveKeyWithValue(bundle.RedactedAnnotation, "true"))
+
+			applicationExport := &appstudioredhatcomv1alpha1.ApplicationExport{}
+			Eventually(func() string {
+				err := k8sClient.Get(ctx, types.NamespacedName{Name: "exportapp", Namespace: "export-foo"}, applicationExport)
+				if err != nil {
+					return ""
+				}
+				return applicationExport.Status.LastSuccessfulAttempt
+			}, timeout, interval).ShouldNot(BeEmpty())
+			Expect(applicationExport.Status.BundleVersion).To(Equal(bundle.APIVersion))
+		})
+	})
+})
@@ -0,0 +1,152 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+var _ = Describe("Approvals", func() {
+
+	It("Should pause the clones of protected Applications until approved", func() {
+		testScheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		applicationClone := &appstudioredhatcomv1alpha1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar", Generation: 1, UID: "billing-uid"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+				From: appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "billing-app"},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(testScheme).
+			WithObjects(
+				applicationClone,
+				&hasApplicationAPI.Application{
+					ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "foo", Annotations: map[string]string{clone.ProtectedAnnotation: "true"}},
+				},
+			).
+			WithStatusSubresource(applicationClone).
+			Build()
+
+		reconciler := &ApplicationCloneReconciler{Client: c, Scheme: testScheme}
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "bar", Name: "billing"}}
+		result, err := reconciler.Reconcile(context.Background(), request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).NotTo(BeZero())
+
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationClone), applicationClone)).To(Succeed())
+		Expect(applicationClone.Status.PendingApproval).To(BeTrue())
+		Expect(applicationClone.Status.Error).To(BeEmpty())
+		Expect(applicationClone.Status.LastSuccessfulAttempt).To(BeEmpty())
+
+		// approving the clone reconciles it
+		approval := &appstudioredhatcomv1alpha1.ApplicationCloneApproval{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-to-bar", Namespace: "foo"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+				Application:    "billing-app",
+				Clone:          appstudioredhatcomv1alpha1.CloneReference{Namespace: "bar", Name: "billing", Generation: 1, UID: "billing-uid"},
+				ExpirationTime: metav1.NewTime(time.Now().Add(time.Hour)),
+				Approver:       "alice",
+			},
+		}
+		Expect(c.Create(context.Background(), approval)).To(Succeed())
+		Expect(approvedClone(context.Background(), approval)).To(Equal([]ctrl.Request{request}))
+
+		_, err = reconciler.Reconcile(context.Background(), request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationClone), applicationClone)).To(Succeed())
+		Expect(applicationClone.Status.PendingApproval).To(BeFalse())
+		Expect(applicationClone.Status.LastSuccessfulAttempt).NotTo(BeEmpty())
+		Expect(applicationClone.Status.Approval.Name).To(Equal("billing-to-bar"))
+		Expect(applicationClone.Status.Approval.Approver).To(Equal("alice"))
+
+		// a change of the spec needs a new approval
+		applicationClone.Spec.DefaultSecretStrategy = appstudioredhatcomv1alpha1.SecretStrategyCopy
+		applicationClone.Generation = 2
+		Expect(c.Update(context.Background(), applicationClone)).To(Succeed())
+		_, err = reconciler.Reconcile(context.Background(), request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationClone), applicationClone)).To(Succeed())
+		Expect(applicationClone.Generation).To(BeEquivalentTo(2))
+		Expect(applicationClone.Status.PendingApproval).To(BeTrue())
+	})
+
+	It("Should only admit approvals of the users allowed to update the Application", func() {
+		testScheme := runtime.NewScheme()
+		Expect(authorizationv1.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		var reviews []authorizationv1.SubjectAccessReviewSpec
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review := obj.(*authorizationv1.SubjectAccessReview)
+				reviews = append(reviews, review.Spec)
+				review.Status.Allowed = review.Spec.User == "alice"
+				return nil
+			},
+		}).Build()
+		validator := &ApplicationCloneApprovalValidator{Client: c}
+		approval := &appstudioredhatcomv1alpha1.ApplicationCloneApproval{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-to-bar", Namespace: "foo"},
+			Spec:       appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{Application: "billing-app"},
+		}
+		request := func(user string) context.Context {
+			return admission.NewContextWithRequest(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{Username: user, Groups: []string{"billing-team"}},
+			}})
+		}
+
+		_, err := validator.ValidateCreate(request("alice"), approval)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reviews).To(Equal([]authorizationv1.SubjectAccessReviewSpec{{
+			User:   "alice",
+			Groups: []string{"billing-team"},
+			Extra:  map[string]authorizationv1.ExtraValue{},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: "foo", Verb: "update", Group: "appstudio.redhat.com", Resource: "applications", Name: "billing-app",
+			},
+		}}))
+
+		_, err = validator.ValidateUpdate(request("mallory"), approval, approval)
+		Expect(err).To(MatchError("mallory may not approve clones of application billing-app, as they aren't allowed to update it"))
+	})
+})
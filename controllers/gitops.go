@@ -0,0 +1,235 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+)
+
+const (
+	// defaultGitOpsBranch is the branch manifests are committed to when none is given
+	defaultGitOpsBranch = "main"
+
+	gitOpsAuthorName  = "clone-controller"
+	gitOpsAuthorEmail = "clone-controller@appstudio.redhat.com"
+)
+
+// gitOpsProtocols are the protocols of the repositories manifests are committed to. Credentials are only given as
+// HTTP basic auth, which mustn't be sent in the clear.
+var gitOpsProtocols = map[string]bool{"https": true}
+
+// validateGitOpsTarget checks that the target is a remote HTTPS repository and that its path is a directory of the
+// repository other than its root. The content of the directory is replaced by the manifests, and the controller
+// must not read or write repositories of its own filesystem, nor fall back to its own SSH keys.
+func validateGitOpsTarget(target *appstudioredhatcomv1alpha1.GitOpsTarget) error {
+	endpoint, err := transport.NewEndpoint(target.URL)
+	if err != nil {
+		return fmt.Errorf("error parsing gitOps.url: %w", err)
+	}
+	if endpoint.Protocol == "file" || endpoint.Host == "" {
+		return fmt.Errorf("gitOps.url must be the URL of a remote repository, not %q", target.URL)
+	}
+	if !gitOpsProtocols[endpoint.Protocol] {
+		return fmt.Errorf("gitOps.url must be an https:// URL, not %q", target.URL)
+	}
+
+	if target.Path == "" {
+		return nil
+	}
+	cleaned := path.Clean(target.Path)
+	if path.IsAbs(target.Path) || cleaned != target.Path || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return fmt.Errorf("gitOps.path must be a clean relative path below the root of the repository, not %q", target.Path)
+	}
+	return nil
+}
+
+// gitAuth returns the credentials for the repository from the kubernetes.io/basic-auth Secret. The
+// repository is accessed anonymously when no Secret is given.
+func gitAuth(ctx context.Context, c client.Reader, namespace, secretName string) (transport.AuthMethod, error) {
+	if secretName == "" {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("error reading git credentials secret %s: %w", secretName, err)
+	}
+
+	password, ok := secret.Data[corev1.BasicAuthPasswordKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no %q key", secretName, corev1.BasicAuthPasswordKey)
+	}
+	username := string(secret.Data[corev1.BasicAuthUsernameKey])
+	if username == "" {
+		// token based authentication only requires a non empty username
+		username = gitOpsAuthorName
+	}
+
+	return &githttp.BasicAuth{Username: username, Password: string(password)}, nil
+}
+
+// checkGitOpsSecrets refuses plans that would push the values of Secrets to the repository: in GitOps mode, Secrets
+// are only cloned as placeholders or referenced
+func checkGitOpsSecrets(plan *clone.Plan) error {
+	for _, obj := range plan.Objects {
+		if obj.GetObjectKind().GroupVersionKind().Kind == "Secret" && !plan.Placeholder(obj) {
+			return fmt.Errorf("secret %s would be committed with its values, clone it as a Placeholder or Reference in GitOps mode", obj.GetName())
+		}
+	}
+	return nil
+}
+
+// commitManifests writes the objects as YAML manifests to the directory of the target, replacing its
+// content, then commits and pushes them. It returns the SHA of the branch head; no commit is made when
+// the manifests are unchanged.
+func commitManifests(ctx context.Context, target *appstudioredhatcomv1alpha1.GitOpsTarget, dir string, auth transport.AuthMethod, objects []client.Object, message string) (string, error) {
+	branch := target.Branch
+	if branch == "" {
+		branch = defaultGitOpsBranch
+	}
+	ref := plumbing.NewBranchReferenceName(branch)
+
+	repository, err := checkoutBranch(ctx, target.URL, ref, auth)
+	if err != nil {
+		return "", err
+	}
+
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("error reading worktree of %s: %w", target.URL, err)
+	}
+
+	err = writeManifests(worktree.Filesystem, dir, objects)
+	if err != nil {
+		return "", err
+	}
+
+	err = worktree.AddWithOptions(&git.AddOptions{All: true})
+	if err != nil {
+		return "", fmt.Errorf("error adding manifests: %w", err)
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return "", fmt.Errorf("error reading worktree status of %s: %w", target.URL, err)
+	}
+	if status.IsClean() {
+		head, err := repository.Head()
+		if err != nil {
+			return "", fmt.Errorf("error reading head of %s: %w", branch, err)
+		}
+		return head.Hash().String(), nil
+	}
+
+	commit, err := worktree.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: gitOpsAuthorName, Email: gitOpsAuthorEmail, When: time.Now()},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error committing manifests: %w", err)
+	}
+
+	err = repository.PushContext(ctx, &git.PushOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(ref + ":" + ref)},
+		Auth:       auth,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error pushing to %s of %s: %w", branch, target.URL, err)
+	}
+
+	return commit.String(), nil
+}
+
+// checkoutBranch clones the branch of the repository in memory. A repository with an orphan branch is
+// returned when the repository is empty or the branch doesn't exist yet.
+func checkoutBranch(ctx context.Context, url string, ref plumbing.ReferenceName, auth transport.AuthMethod) (*git.Repository, error) {
+	repository, err := git.CloneContext(ctx, memory.NewStorage(), memfs.New(), &git.CloneOptions{
+		URL:           url,
+		Auth:          auth,
+		ReferenceName: ref,
+		SingleBranch:  true,
+	})
+	if err == nil {
+		return repository, nil
+	}
+
+	var noMatchingRefSpec git.NoMatchingRefSpecError
+	if !errors.Is(err, transport.ErrEmptyRemoteRepository) && !errors.Is(err, plumbing.ErrReferenceNotFound) && !errors.As(err, &noMatchingRefSpec) {
+		return nil, fmt.Errorf("error cloning %s: %w", url, err)
+	}
+
+	repository, err = git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		return nil, fmt.Errorf("error initializing repository: %w", err)
+	}
+	err = repository.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, ref))
+	if err != nil {
+		return nil, fmt.Errorf("error checking out %s: %w", ref.Short(), err)
+	}
+	_, err = repository.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{url}})
+	if err != nil {
+		return nil, fmt.Errorf("error adding remote %s: %w", url, err)
+	}
+
+	return repository, nil
+}
+
+// writeManifests replaces the content of the directory with one manifest per object
+func writeManifests(fs billy.Filesystem, dir string, objects []client.Object) error {
+	err := util.RemoveAll(fs, dir)
+	if err != nil {
+		return fmt.Errorf("error cleaning %s: %w", dir, err)
+	}
+
+	for _, obj := range objects {
+		data, err := clone.RenderManifest(obj)
+		if err != nil {
+			return err
+		}
+
+		kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+		err = util.WriteFile(fs, path.Join(dir, kind+"-"+obj.GetName()+".yaml"), data, 0644)
+		if err != nil {
+			return fmt.Errorf("error writing manifest of %s %s: %w", kind, obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	gitclient "github.com/go-git/go-git/v5/plumbing/transport/client"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+// testGitScheme serves local repositories in process, as if they were remote: the controller refuses local
+// repositories
+const testGitScheme = "gittest"
+
+func init() {
+	gitclient.InstallProtocol(testGitScheme, server.DefaultServer)
+	gitOpsProtocols[testGitScheme] = true
+}
+
+// committedFiles returns the files of the commit in the repository of the directory, with their content
+func committedFiles(dir, branch, sha string) map[string]string {
+	repository, err := git.PlainOpen(dir)
+	Expect(err).NotTo(HaveOccurred())
+
+	head, err := repository.Reference(plumbing.NewBranchReferenceName(branch), true)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(head.Hash().String()).To(Equal(sha))
+
+	commit, err := repository.CommitObject(head.Hash())
+	Expect(err).NotTo(HaveOccurred())
+	files, err := commit.Files()
+	Expect(err).NotTo(HaveOccurred())
+
+	content := map[string]string{}
+	Expect(files.ForEach(func(f *object.File) error {
+		content[f.Name], err = f.Contents()
+		return err
+	})).To(Succeed())
+	return content
+}
+
+func fileNames(m map[string]string) []string {
+	k := make([]string, 0, len(m))
+	for key := range m {
+		k = append(k, key)
+	}
+	sort.Strings(k)
+	return k
+}
+
+var _ = Describe("GitOps mode", func() {
+
+	var testScheme *runtime.Scheme
+	var dir, url string
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		dir = GinkgoT().TempDir()
+		_, err := git.PlainInit(dir, true)
+		Expect(err).NotTo(HaveOccurred())
+		url = testGitScheme + "://localhost" + dir
+	})
+
+	It("Should only commit changed manifests", func() {
+		target := &appstudioredhatcomv1alpha1.GitOpsTarget{URL: url}
+		objects := []client.Object{
+			&hasApplicationAPI.Application{
+				TypeMeta:   metav1.TypeMeta{APIVersion: hasApplicationAPI.GroupVersion.String(), Kind: "Application"},
+				ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "bar"},
+			},
+			&hasApplicationAPI.Component{
+				TypeMeta:   metav1.TypeMeta{APIVersion: hasApplicationAPI.GroupVersion.String(), Kind: "Component"},
+				ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "bar"},
+				Spec:       hasApplicationAPI.ComponentSpec{Application: "billing-app", ContainerImage: "quay.io/foo/c1"},
+			},
+		}
+
+		first, err := commitManifests(context.Background(), target, "clones/bar", nil, objects, "Clone")
+		Expect(err).NotTo(HaveOccurred())
+		files := committedFiles(dir, "main", first)
+		Expect(fileNames(files)).To(Equal([]string{"clones/bar/application-billing-app.yaml", "clones/bar/component-c1.yaml"}))
+		Expect(files["clones/bar/component-c1.yaml"]).To(ContainSubstring("containerImage: quay.io/foo/c1"))
+		Expect(files["clones/bar/component-c1.yaml"]).NotTo(ContainSubstring("status"))
+		Expect(files["clones/bar/component-c1.yaml"]).NotTo(ContainSubstring("creationTimestamp"))
+
+		unchanged, err := commitManifests(context.Background(), target, "clones/bar", nil, objects, "Clone")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unchanged).To(Equal(first))
+
+		second, err := commitManifests(context.Background(), target, "clones/bar", nil, objects[:1], "Clone")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).NotTo(Equal(first))
+		Expect(fileNames(committedFiles(dir, "main", second))).To(Equal([]string{"clones/bar/application-billing-app.yaml"}))
+
+		target.Branch = "staging"
+		other, err := commitManifests(context.Background(), target, "clones/bar", nil, objects, "Clone")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fileNames(committedFiles(dir, "staging", other))).To(HaveLen(2))
+	})
+
+	It("Should commit the cloned resources instead of creating them", func() {
+		applicationClone := &appstudioredhatcomv1alpha1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "bundleapp", Namespace: "bar"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+				From: appstudioredhatcomv1alpha1.From{
+					Bundle: &appstudioredhatcomv1alpha1.BundleSource{
+						ConfigMap: &appstudioredhatcomv1alpha1.ConfigMapBundleSource{Name: "template"},
+					},
+				},
+				ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{{Name: "bundle-c1"}},
+				GitOps:           &appstudioredhatcomv1alpha1.GitOpsTarget{URL: url},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(testScheme).
+			WithObjects(applicationClone, newBundleConfigMap("template", "bar")).
+			WithStatusSubresource(applicationClone).
+			Build()
+
+		reconciler := &ApplicationCloneReconciler{Client: c, Scheme: testScheme}
+		_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "bar", Name: "bundleapp"}})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationClone), applicationClone)).To(Succeed())
+		Expect(applicationClone.Status.Error).To(BeEmpty())
+		Expect(applicationClone.Status.Resources).To(ConsistOf(
+			appstudioredhatcomv1alpha1.Resource{Kind: "Application", Name: "bundleapp"},
+			appstudioredhatcomv1alpha1.Resource{Kind: "Component", Name: "bundle-c1"},
+		))
+		Expect(fileNames(committedFiles(dir, "main", applicationClone.Status.Commit))).To(Equal([]string{"bar/bundleapp/application-bundleapp.yaml", "bar/bundleapp/component-bundle-c1.yaml"}))
+
+		err = c.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "bundleapp"}, &hasApplicationAPI.Application{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("Should refuse local repositories and paths outside of the repository", func() {
+		for _, url := range []string{"/var/lib/repo", "file:///var/lib/repo", "../repo"} {
+			Expect(validateGitOpsTarget(&appstudioredhatcomv1alpha1.GitOpsTarget{URL: url})).
+				To(MatchError(ContainSubstring("must be the URL of a remote repository")), url)
+		}
+		for _, path := range []string{".", "/", "/clones", "..", "../clones", "clones/../..", "clones/", "./clones", "clones//bar"} {
+			Expect(validateGitOpsTarget(&appstudioredhatcomv1alpha1.GitOpsTarget{URL: "https://github.com/org/gitops.git", Path: path})).
+				To(MatchError(ContainSubstring("gitOps.path must be a clean relative path")), path)
+		}
+		// credentials are only given over HTTPS
+		for _, url := range []string{"http://github.com/org/gitops.git", "git@github.com:org/gitops.git", "ssh://git@github.com/org/gitops.git"} {
+			Expect(validateGitOpsTarget(&appstudioredhatcomv1alpha1.GitOpsTarget{URL: url, Path: "clones/bar"})).
+				To(MatchError(ContainSubstring("gitOps.url must be an https:// URL")), url)
+		}
+		Expect(validateGitOpsTarget(&appstudioredhatcomv1alpha1.GitOpsTarget{URL: "https://github.com/org/gitops.git", Path: "clones/bar"})).To(Succeed())
+	})
+
+	It("Should refuse to commit the values of Secrets", func() {
+		component := &hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "foo"},
+			Spec:       hasApplicationAPI.ComponentSpec{Application: "billing-app", ComponentName: "c1", Secret: "git-token"},
+		}
+		source := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "foo"}},
+			component,
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "git-token", Namespace: "foo"}, Data: map[string][]byte{"password": []byte("s3cr3t")}},
+		).Build()
+		planner := &clone.Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+		plan := func(strategy appstudioredhatcomv1alpha1.SecretStrategy) *clone.Plan {
+			p, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+				From:                  appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "billing-app"},
+				DefaultSecretStrategy: strategy,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return p
+		}
+
+		Expect(checkGitOpsSecrets(plan(appstudioredhatcomv1alpha1.SecretStrategyCopy))).
+			To(MatchError("secret git-token would be committed with its values, clone it as a Placeholder or Reference in GitOps mode"))
+		Expect(checkGitOpsSecrets(plan(appstudioredhatcomv1alpha1.SecretStrategyPlaceholder))).To(Succeed())
+		Expect(checkGitOpsSecrets(plan(appstudioredhatcomv1alpha1.SecretStrategyReference))).To(Succeed())
+	})
+})
@@ -0,0 +1,93 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// dockerConfig is the content of a kubernetes.io/dockerconfigjson Secret
+type dockerConfig struct {
+	Auths map[string]authn.AuthConfig `json:"auths"`
+}
+
+// secretKeychain resolves registry credentials from a docker config
+type secretKeychain struct {
+	auths map[string]authn.AuthConfig
+}
+
+// Resolve implements authn.Keychain
+func (k *secretKeychain) Resolve(target authn.Resource) (authn.Authenticator, error) {
+	registry := normalizeRegistry(target.RegistryStr())
+	for host, auth := range k.auths {
+		if normalizeRegistry(host) == registry {
+			return authn.FromConfig(auth), nil
+		}
+	}
+	return authn.Anonymous, nil
+}
+
+// normalizeRegistry strips the scheme and path docker config keys may have, e.g. "https://index.docker.io/v1/"
+func normalizeRegistry(host string) string {
+	host = strings.TrimPrefix(host, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	host, _, _ = strings.Cut(host, "/")
+	if host == "docker.io" {
+		return "index.docker.io"
+	}
+	return host
+}
+
+// registryOptions returns the options to access a registry with the credentials of the given
+// kubernetes.io/dockerconfigjson or kubernetes.io/dockercfg Secret. The registry is accessed
+// anonymously when no Secret is given.
+func registryOptions(ctx context.Context, c client.Reader, namespace, secretName string) ([]remote.Option, error) {
+	if secretName == "" {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("error reading registry credentials secret %s: %w", secretName, err)
+	}
+
+	config := dockerConfig{}
+	switch {
+	case secret.Data[corev1.DockerConfigJsonKey] != nil:
+		err = json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config)
+	case secret.Data[corev1.DockerConfigKey] != nil:
+		// the legacy format holds the auths only
+		err = json.Unmarshal(secret.Data[corev1.DockerConfigKey], &config.Auths)
+	default:
+		return nil, fmt.Errorf("secret %s has no %q key", secretName, corev1.DockerConfigJsonKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing registry credentials secret %s: %w", secretName, err)
+	}
+
+	return []remote.Option{remote.WithAuthFromKeychain(&secretKeychain{auths: config.Auths})}, nil
+}
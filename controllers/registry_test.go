@@ -0,0 +1,165 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+// newAuthenticatedRegistry starts an in-memory registry requiring basic authentication
+func newAuthenticatedRegistry(username, password string) *httptest.Server {
+	handler := registry.New()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u, p, ok := r.BasicAuth(); !ok || u != username || p != password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+}
+
+func newRegistryCredentials(name, namespace, host, username, password string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(fmt.Sprintf(`{"auths":{%q:{"username":%q,"password":%q}}}`, host, username, password)),
+		},
+	}
+}
+
+var _ = Describe("Registry credentials", func() {
+
+	It("Should resolve the credentials of the registry", func() {
+		keychain := &secretKeychain{auths: map[string]authn.AuthConfig{
+			"https://index.docker.io/v1/": {Username: "hub", Password: "hub-password"},
+			"quay.io":                     {Username: "quay", Password: "quay-password"},
+		}}
+
+		for reference, username := range map[string]string{
+			"docker.io/foo/bar":      "hub",
+			"quay.io/foo/bar":        "quay",
+			"registry.local/foo/bar": "",
+		} {
+			ref, err := name.ParseReference(reference)
+			Expect(err).NotTo(HaveOccurred())
+
+			authenticator, err := keychain.Resolve(ref.Context())
+			Expect(err).NotTo(HaveOccurred())
+			config, err := authenticator.Authorization()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Username).To(Equal(username), reference)
+		}
+	})
+
+	It("Should reject Secrets without a docker config", func() {
+		c := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "bar"},
+			Data:       map[string][]byte{"token": []byte("hunter2")},
+		}).Build()
+
+		_, err := registryOptions(context.Background(), c, "bar", "token")
+		Expect(err).To(MatchError(ContainSubstring(`has no ".dockerconfigjson" key`)))
+
+		_, err = registryOptions(context.Background(), c, "bar", "missing")
+		Expect(err).To(HaveOccurred())
+
+		opts, err := registryOptions(context.Background(), c, "bar", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(opts).To(BeEmpty())
+	})
+
+	It("Should export to and clone from an OCI artifact with credentials", func() {
+		server := newAuthenticatedRegistry("robot", "s3cr3t")
+		defer server.Close()
+		host := strings.TrimPrefix(server.URL, "http://")
+		reference := host + "/templates/exportapp:v1"
+
+		testScheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		c := fake.NewClientBuilder().WithScheme(testScheme).
+			WithRuntimeObjects(newExportSource()...).
+			WithObjects(newRegistryCredentials("registry", "export-foo", host, "robot", "s3cr3t")).
+			Build()
+
+		applicationExport := &appstudioredhatcomv1alpha1.ApplicationExport{
+			ObjectMeta: metav1.ObjectMeta{Name: "exportapp", Namespace: "export-foo"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationExportSpec{
+				From: appstudioredhatcomv1alpha1.From{Namespace: "export-foo", Name: "exportapp"},
+				To: appstudioredhatcomv1alpha1.ExportTarget{
+					OCI: &appstudioredhatcomv1alpha1.OCIArtifact{Reference: reference},
+				},
+			},
+		}
+
+		exporter := &ApplicationExportReconciler{Client: c, Scheme: testScheme}
+		_, err := exporter.export(context.Background(), applicationExport)
+		Expect(err).To(MatchError(ContainSubstring("401 Unauthorized")))
+
+		applicationExport.Spec.To.OCI.CredentialsSecret = "registry"
+		exported, err := exporter.export(context.Background(), applicationExport)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exported.digest).To(HavePrefix("sha256:"))
+		Expect(exported.configMap).To(BeEmpty())
+
+		cloner := &ApplicationCloneReconciler{Client: c, Scheme: testScheme}
+		source, from, err := cloner.source(context.Background(), &appstudioredhatcomv1alpha1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "clone", Namespace: "export-foo"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+				From: appstudioredhatcomv1alpha1.From{
+					Bundle: &appstudioredhatcomv1alpha1.BundleSource{
+						OCI: &appstudioredhatcomv1alpha1.OCIArtifact{
+							Reference:         host + "/templates/exportapp@" + exported.digest,
+							CredentialsSecret: "registry",
+						},
+					},
+				},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(from.Name).To(Equal("exportapp"))
+
+		application := &hasApplicationAPI.Application{}
+		Expect(source.Get(context.Background(), types.NamespacedName{Namespace: from.Namespace, Name: from.Name}, application)).To(Succeed())
+		Expect(application.Spec.DisplayName).To(Equal("Export"))
+	})
+})
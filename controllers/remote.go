@@ -0,0 +1,152 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+// defaultKubeconfigKey is the Secret data key a ClusterRef's kubeconfig is read from when none is given
+const defaultKubeconfigKey = "kubeconfig"
+
+// remoteClients caches the clients for the remote clusters referenced by ApplicationClones, keyed by
+// their kubeconfig Secret. A client is rebuilt when the Secret changes and dropped when it is deleted or
+// no longer holds a valid kubeconfig. The zero value is ready to use.
+type remoteClients struct {
+	mu      sync.Mutex
+	clients map[types.NamespacedName]remoteClient
+}
+
+type remoteClient struct {
+	// resourceVersion of the Secret the client was built from
+	resourceVersion string
+	client          client.Client
+}
+
+// get returns the client for the cluster described by the kubeconfig Secret, building it if needed.
+func (r *remoteClients) get(secret *corev1.Secret, key string, scheme *runtime.Scheme) (client.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	secretName := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+	if cached, ok := r.clients[secretName]; ok && cached.resourceVersion == secret.ResourceVersion {
+		return cached.client, nil
+	}
+
+	// a changed Secret that no longer holds a valid kubeconfig must not leave the previous client usable
+	delete(r.clients, secretName)
+
+	kubeconfig, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s has no %q key", secretName, key)
+	}
+
+	config, err := restConfigFromKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kubeconfig from secret %s: %w", secretName, err)
+	}
+
+	c, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("error creating client from secret %s: %w", secretName, err)
+	}
+
+	if r.clients == nil {
+		r.clients = map[types.NamespacedName]remoteClient{}
+	}
+	r.clients[secretName] = remoteClient{resourceVersion: secret.ResourceVersion, client: c}
+
+	return c, nil
+}
+
+// forget drops the client built from the kubeconfig Secret, e.g. once the Secret is deleted.
+func (r *remoteClients) forget(secretName types.NamespacedName) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.clients, secretName)
+}
+
+// restConfigFromKubeconfig returns the client configuration of the current context of the kubeconfig. The
+// kubeconfig comes from a Secret that tenants write, so everything that would make the controller run a
+// command, read one of its own files or hand out its credentials is refused: exec and auth-provider
+// plugins, and the paths of tokens, certificates and keys. Credentials and certificates must be inline.
+func restConfigFromKubeconfig(kubeconfig []byte) (*rest.Config, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, authInfo := range config.AuthInfos {
+		switch {
+		case authInfo.Exec != nil:
+			return nil, fmt.Errorf("user %q: exec plugins are not supported", name)
+		case authInfo.AuthProvider != nil:
+			return nil, fmt.Errorf("user %q: auth providers are not supported", name)
+		case authInfo.TokenFile != "":
+			return nil, fmt.Errorf("user %q: tokenFile is not supported, use token", name)
+		case authInfo.ClientCertificate != "":
+			return nil, fmt.Errorf("user %q: client-certificate is not supported, use client-certificate-data", name)
+		case authInfo.ClientKey != "":
+			return nil, fmt.Errorf("user %q: client-key is not supported, use client-key-data", name)
+		}
+	}
+	for name, cluster := range config.Clusters {
+		if cluster.CertificateAuthority != "" {
+			return nil, fmt.Errorf("cluster %q: certificate-authority is not supported, use certificate-authority-data", name)
+		}
+	}
+
+	return clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+}
+
+// clientFor returns the client to read source resources with: the client of the cluster described by
+// the kubeconfig Secret in the given namespace when a ClusterRef is given, the local client otherwise.
+func (r *remoteClients) clientFor(ctx context.Context, local client.Client, scheme *runtime.Scheme, namespace string, clusterRef *appstudioredhatcomv1alpha1.ClusterRef) (client.Client, error) {
+	if clusterRef == nil {
+		return local, nil
+	}
+
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{Namespace: namespace, Name: clusterRef.SecretName}
+	err := local.Get(ctx, secretName, secret)
+	if errors.IsNotFound(err) {
+		r.forget(secretName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster secret %s: %w", clusterRef.SecretName, err)
+	}
+
+	key := clusterRef.Key
+	if key == "" {
+		key = defaultKubeconfigKey
+	}
+
+	return r.get(secret, key, scheme)
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+var _ = Describe("ApplicationClone controller", func() {
+
+	Context("When cloning from a remote cluster", func() {
+		It("Should read the source resources from the cluster referenced by the kubeconfig Secret", func() {
+			ctx := context.Background()
+
+			By("By creating an Application and a Component in the remote cluster")
+			namespace := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "remote-foo"}}
+			Expect(remoteK8sClient.Create(ctx, &namespace)).To(Succeed())
+
+			Expect(remoteK8sClient.Create(ctx, &hasApplicationAPI.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "remoteapp",
+					Namespace: "remote-foo",
+				},
+			})).To(Succeed())
+
+			Expect(remoteK8sClient.Create(ctx, &hasApplicationAPI.Component{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "remote-c1",
+					Namespace: "remote-foo",
+				},
+				Spec: hasApplicationAPI.ComponentSpec{
+					ComponentName: "remote-component",
+					Application:   "remoteapp",
+					Source: hasApplicationAPI.ComponentSource{
+						ComponentSourceUnion: hasApplicationAPI.ComponentSourceUnion{
+							GitSource: &hasApplicationAPI.GitSource{
+								URL: "github.com/foo/remote-c1",
+							},
+						},
+					},
+				},
+			})).To(Succeed())
+
+			By("By storing the remote cluster's kubeconfig in a Secret")
+			createNamespace(ctx, "remote-bar")
+
+			remoteUser, err := remoteTestEnv.AddUser(envtest.User{Name: "clone-controller", Groups: []string{"system:masters"}}, nil)
+			Expect(err).NotTo(HaveOccurred())
+			kubeconfig, err := remoteUser.KubeConfig()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "remote-cluster",
+					Namespace: "remote-bar",
+				},
+				Data: map[string][]byte{
+					"kubeconfig": kubeconfig,
+				},
+			})).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &appstudioredhatcomv1alpha1.ApplicationClone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "remoteapp",
+					Namespace: "remote-bar",
+				},
+				Spec: appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+					From: appstudioredhatcomv1alpha1.From{
+						Name:      "remoteapp",
+						Namespace: "remote-foo",
+						ClusterRef: &appstudioredhatcomv1alpha1.ClusterRef{
+							SecretName: "remote-cluster",
+						},
+					},
+					ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{
+						{
+							Name: "remote-c1",
+						},
+					},
+				},
+			})).To(Succeed())
+
+			clonedComponent := &hasApplicationAPI.Component{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "remote-c1",
+					Namespace: "remote-bar",
+				}, clonedComponent)
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(clonedComponent.Spec.Source.GitSource.URL).To(Equal("github.com/foo/remote-c1"))
+		})
+	})
+})
+
+var _ = Describe("Remote cluster clients", func() {
+
+	kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com:6443
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+current-context: remote
+users:
+- name: remote
+  user:
+    token: secret-token
+`)
+
+	newSecret := func(resourceVersion string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "remote-cluster",
+				Namespace:       "bar",
+				ResourceVersion: resourceVersion,
+			},
+			Data: map[string][]byte{
+				"kubeconfig": kubeconfig,
+			},
+		}
+	}
+
+	It("Should reuse the client until the Secret changes", func() {
+		clients := remoteClients{}
+		testScheme := runtime.NewScheme()
+
+		first, err := clients.get(newSecret("1"), defaultKubeconfigKey, testScheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		cached, err := clients.get(newSecret("1"), defaultKubeconfigKey, testScheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached).To(BeIdenticalTo(first))
+
+		rebuilt, err := clients.get(newSecret("2"), defaultKubeconfigKey, testScheme)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rebuilt).NotTo(BeIdenticalTo(first))
+	})
+
+	It("Should fail when the Secret has no kubeconfig", func() {
+		clients := remoteClients{}
+
+		_, err := clients.get(newSecret("1"), "other", runtime.NewScheme())
+		Expect(err).To(MatchError(ContainSubstring(`no "other" key`)))
+	})
+
+	It("Should drop the client once the Secret no longer holds a valid kubeconfig", func() {
+		clients := remoteClients{}
+		testScheme := runtime.NewScheme()
+
+		_, err := clients.get(newSecret("1"), defaultKubeconfigKey, testScheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		broken := newSecret("2")
+		broken.Data[defaultKubeconfigKey] = []byte("not a kubeconfig")
+		_, err = clients.get(broken, defaultKubeconfigKey, testScheme)
+		Expect(err).To(HaveOccurred())
+		Expect(clients.clients).NotTo(HaveKey(types.NamespacedName{Namespace: "bar", Name: "remote-cluster"}))
+	})
+
+	It("Should drop the client once the Secret is deleted", func() {
+		clients := remoteClients{}
+		testScheme := runtime.NewScheme()
+
+		_, err := clients.get(newSecret("1"), defaultKubeconfigKey, testScheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		local := fake.NewClientBuilder().WithScheme(testScheme).Build()
+		_, err = clients.clientFor(context.Background(), local, testScheme, "bar", &appstudioredhatcomv1alpha1.ClusterRef{SecretName: "remote-cluster"})
+		Expect(err).To(HaveOccurred())
+		Expect(clients.clients).To(BeEmpty())
+	})
+
+	DescribeTable("Should refuse kubeconfigs that reach outside the Secret",
+		func(user, cluster string) {
+			kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: https://remote.example.com:6443
+` + cluster + `
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: remote
+current-context: remote
+users:
+- name: remote
+  user:
+` + user + `
+`)
+			_, err := restConfigFromKubeconfig(kubeconfig)
+			Expect(err).To(MatchError(ContainSubstring("not supported")))
+		},
+		Entry("exec plugin", "    exec:\n      apiVersion: client.authentication.k8s.io/v1\n      command: sh", ""),
+		Entry("auth provider", "    auth-provider:\n      name: oidc", ""),
+		Entry("token file", "    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token", ""),
+		Entry("client certificate path", "    client-certificate: /etc/tls/tls.crt", ""),
+		Entry("client key path", "    client-key: /etc/tls/tls.key", ""),
+		Entry("certificate authority path", "    token: secret-token", "    certificate-authority: /etc/ssl/ca.crt"),
+	)
+})
@@ -0,0 +1,103 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+var _ = Describe("Placeholder Secrets", func() {
+
+	It("Should report the placeholder Secrets until they are filled in", func() {
+		testScheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		applicationClone := &appstudioredhatcomv1alpha1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing", Namespace: "bar"},
+			Spec: appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+				From:                  appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "billing-app"},
+				DefaultSecretStrategy: appstudioredhatcomv1alpha1.SecretStrategyPlaceholder,
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(testScheme).
+			WithObjects(
+				applicationClone,
+				&hasApplicationAPI.Application{
+					ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "foo"},
+					Spec:       hasApplicationAPI.ApplicationSpec{DisplayName: "billing-app"},
+				},
+				&hasApplicationAPI.Component{
+					ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "foo"},
+					Spec:       hasApplicationAPI.ComponentSpec{ComponentName: "c1", Application: "billing-app", Secret: "git-token"},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "git-token", Namespace: "foo"},
+					Data:       map[string][]byte{"password": []byte("s3cr3t")},
+				},
+			).
+			WithStatusSubresource(applicationClone).
+			Build()
+
+		reconciler := &ApplicationCloneReconciler{Client: c, Scheme: testScheme}
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "bar", Name: "billing"}}
+		_, err := reconciler.Reconcile(context.Background(), request)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationClone), applicationClone)).To(Succeed())
+		Expect(applicationClone.Status.Error).To(BeEmpty())
+		Expect(applicationClone.Status.PendingSecrets).To(Equal([]string{"git-token"}))
+
+		// filling the Secret in reconciles its ApplicationClone
+		secret := &corev1.Secret{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "git-token"}, secret)).To(Succeed())
+		Expect(secret.Annotations).To(HaveKeyWithValue(clone.PlaceholderAnnotation, "true"))
+		Expect(secret.Data).To(Equal(map[string][]byte{"password": {}}))
+		secret.Data["password"] = []byte("t0k3n")
+		Expect(c.Update(context.Background(), secret)).To(Succeed())
+		Expect(placeholderSecretClone(context.Background(), secret)).To(Equal([]ctrl.Request{request}))
+
+		_, err = reconciler.Reconcile(context.Background(), request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(applicationClone), applicationClone)).To(Succeed())
+		Expect(applicationClone.Status.PendingSecrets).To(BeEmpty())
+	})
+
+	It("Should only map placeholder Secrets to their ApplicationClone", func() {
+		Expect(placeholderSecretClone(context.Background(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-token", Namespace: "bar", Labels: map[string]string{clone.CloneLabel: "billing"}},
+		})).To(BeEmpty())
+	})
+})
@@ -0,0 +1,102 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+)
+
+// source returns the reader for the ApplicationClone's source resources, along with the Application to
+// clone. The resources are read from a bundle, a remote cluster or the local cluster.
+func (r *ApplicationCloneReconciler) source(ctx context.Context, applicationClone *appstudioredhatcomv1alpha1.ApplicationClone) (client.Reader, appstudioredhatcomv1alpha1.From, error) {
+	from := applicationClone.Spec.From
+
+	if from.Bundle == nil {
+		if from.Namespace == "" || from.Name == "" {
+			return nil, from, fmt.Errorf("from.namespace and from.name are required unless from.bundle is set")
+		}
+		c, err := r.remoteClients.clientFor(ctx, r.Client, r.Scheme, applicationClone.Namespace, from.ClusterRef)
+		return c, from, err
+	}
+
+	if from.ClusterRef != nil {
+		return nil, from, fmt.Errorf("from.clusterRef cannot be combined with from.bundle")
+	}
+
+	b, err := readBundle(ctx, r.Client, applicationClone.Namespace, from.Bundle)
+	if err != nil {
+		return nil, from, err
+	}
+
+	if from.Name == "" {
+		from.Name = b.Application.Name
+	}
+	if from.Name != b.Application.Name {
+		return nil, from, fmt.Errorf("bundle holds application %s, not %s", b.Application.Name, from.Name)
+	}
+	// the bundle's objects are served from the ApplicationClone's namespace unless told otherwise
+	if from.Namespace == "" {
+		from.Namespace = applicationClone.Namespace
+	}
+
+	return b.Reader(r.Scheme, from.Namespace), from, nil
+}
+
+// readBundle reads the bundle from its source in the given namespace.
+func readBundle(ctx context.Context, c client.Reader, namespace string, source *appstudioredhatcomv1alpha1.BundleSource) (*bundle.Bundle, error) {
+	if (source.ConfigMap == nil) == (source.OCI == nil) {
+		return nil, fmt.Errorf("exactly one of from.bundle.configMap and from.bundle.oci must be set")
+	}
+
+	if source.OCI != nil {
+		opts, err := registryOptions(ctx, c, namespace, source.OCI.CredentialsSecret)
+		if err != nil {
+			return nil, err
+		}
+		return bundle.Pull(ctx, source.OCI.Reference, opts...)
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: source.ConfigMap.Name}, configMap)
+	if err != nil {
+		return nil, fmt.Errorf("error reading bundle configmap %s: %w", source.ConfigMap.Name, err)
+	}
+
+	key := source.ConfigMap.Key
+	if key == "" {
+		key = bundle.FileName
+	}
+
+	data, ok := configMap.Data[key]
+	if !ok {
+		binaryData, ok := configMap.BinaryData[key]
+		if !ok {
+			return nil, fmt.Errorf("configmap %s has no %q key", configMap.Name, key)
+		}
+		data = string(binaryData)
+	}
+
+	return bundle.Unmarshal([]byte(data))
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+func newBundleConfigMap(name, namespace string) *corev1.ConfigMap {
+	b := bundle.New(&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "bundleapp"}})
+	b.AddComponent(&hasApplicationAPI.Component{
+		ObjectMeta: metav1.ObjectMeta{Name: "bundle-c1"},
+		Spec: hasApplicationAPI.ComponentSpec{
+			ComponentName: "bundle-c1",
+			Application:   "bundleapp",
+			Source: hasApplicationAPI.ComponentSource{
+				ComponentSourceUnion: hasApplicationAPI.ComponentSourceUnion{
+					GitSource: &hasApplicationAPI.GitSource{
+						URL: "github.com/foo/bundle-c1",
+					},
+				},
+			},
+		},
+	})
+	data, err := b.Marshal()
+	Expect(err).NotTo(HaveOccurred())
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{bundle.FileName: string(data)},
+	}
+}
+
+var _ = Describe("ApplicationClone sources", func() {
+
+	var reconciler *ApplicationCloneReconciler
+
+	BeforeEach(func() {
+		testScheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		reconciler = &ApplicationCloneReconciler{
+			Client: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newBundleConfigMap("template", "bar")).Build(),
+			Scheme: testScheme,
+		}
+	})
+
+	newApplicationClone := func(from appstudioredhatcomv1alpha1.From) *appstudioredhatcomv1alpha1.ApplicationClone {
+		return &appstudioredhatcomv1alpha1.ApplicationClone{
+			ObjectMeta: metav1.ObjectMeta{Name: "clone", Namespace: "bar"},
+			Spec:       appstudioredhatcomv1alpha1.ApplicationCloneSpec{From: from},
+		}
+	}
+
+	It("Should read the Application from a bundle", func() {
+		source, from, err := reconciler.source(context.Background(), newApplicationClone(appstudioredhatcomv1alpha1.From{
+			Bundle: &appstudioredhatcomv1alpha1.BundleSource{
+				ConfigMap: &appstudioredhatcomv1alpha1.ConfigMapBundleSource{Name: "template"},
+			},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(from.Name).To(Equal("bundleapp"))
+		Expect(from.Namespace).To(Equal("bar"))
+
+		componentList := &hasApplicationAPI.ComponentList{}
+		Expect(source.List(context.Background(), componentList, &client.ListOptions{Namespace: from.Namespace})).To(Succeed())
+		Expect(componentList.Items).To(HaveLen(1))
+	})
+
+	It("Should reject invalid sources", func() {
+		_, _, err := reconciler.source(context.Background(), newApplicationClone(appstudioredhatcomv1alpha1.From{Name: "appfoo"}))
+		Expect(err).To(MatchError(ContainSubstring("from.namespace and from.name are required")))
+
+		_, _, err = reconciler.source(context.Background(), newApplicationClone(appstudioredhatcomv1alpha1.From{
+			Name: "otherapp",
+			Bundle: &appstudioredhatcomv1alpha1.BundleSource{
+				ConfigMap: &appstudioredhatcomv1alpha1.ConfigMapBundleSource{Name: "template"},
+			},
+		}))
+		Expect(err).To(MatchError(ContainSubstring("bundle holds application bundleapp")))
+
+		_, _, err = reconciler.source(context.Background(), newApplicationClone(appstudioredhatcomv1alpha1.From{
+			Bundle: &appstudioredhatcomv1alpha1.BundleSource{
+				ConfigMap: &appstudioredhatcomv1alpha1.ConfigMapBundleSource{Name: "template", Key: "missing.yaml"},
+			},
+		}))
+		Expect(err).To(MatchError(ContainSubstring(`no "missing.yaml" key`)))
+
+		_, _, err = reconciler.source(context.Background(), newApplicationClone(appstudioredhatcomv1alpha1.From{
+			ClusterRef: &appstudioredhatcomv1alpha1.ClusterRef{SecretName: "remote"},
+			Bundle: &appstudioredhatcomv1alpha1.BundleSource{
+				ConfigMap: &appstudioredhatcomv1alpha1.ConfigMapBundleSource{Name: "template"},
+			},
+		}))
+		Expect(err).To(MatchError(ContainSubstring("cannot be combined")))
+	})
+
+	It("Should apply the ClonePolicies to the namespace a bundle was exported from", func() {
+		testScheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		b := bundle.New(&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "bundleapp", Namespace: "prod-billing"}})
+		data, err := b.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		reconciler.Client = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "prod-template", Namespace: "bar"},
+				Data:       map[string]string{bundle.FileName: string(data)},
+			},
+			&appstudioredhatcomv1alpha1.ClonePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "no-prod"},
+				Spec:       appstudioredhatcomv1alpha1.ClonePolicySpec{BlockedSourceNamespaces: []string{"prod-*"}},
+			},
+		).Build()
+		reconciler.Scheme = testScheme
+
+		_, _, err = reconciler.clone(context.Background(), newApplicationClone(appstudioredhatcomv1alpha1.From{
+			Bundle: &appstudioredhatcomv1alpha1.BundleSource{
+				ConfigMap: &appstudioredhatcomv1alpha1.ConfigMapBundleSource{Name: "prod-template"},
+			},
+		}))
+		Expect(err).To(MatchError("the ApplicationClone violates the ClonePolicies of the cluster: " +
+			"no-prod: Applications exported from namespace prod-billing can't be cloned"))
+	})
+})
+
+var _ = Describe("ApplicationClone controller", func() {
+
+	Context("When cloning from a bundle", func() {
+		It("Should create the Components of the bundle", func() {
+			ctx := context.Background()
+			createNamespace(ctx, "bundle-bar")
+
+			Expect(k8sClient.Create(ctx, newBundleConfigMap("template", "bundle-bar"))).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &appstudioredhatcomv1alpha1.ApplicationClone{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bundleapp",
+					Namespace: "bundle-bar",
+				},
+				Spec: appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+					From: appstudioredhatcomv1alpha1.From{
+						Bundle: &appstudioredhatcomv1alpha1.BundleSource{
+							ConfigMap: &appstudioredhatcomv1alpha1.ConfigMapBundleSource{Name: "template"},
+						},
+					},
+					ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{
+						{
+							Name: "bundle-c1",
+						},
+					},
+				},
+			})).To(Succeed())
+
+			clonedComponent := &hasApplicationAPI.Component{}
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "bundle-c1",
+					Namespace: "bundle-bar",
+				}, clonedComponent)
+				return err == nil
+			}, timeout, interval).Should(BeTrue())
+
+			Expect(clonedComponent.Spec.Application).To(Equal("bundleapp"))
+			Expect(clonedComponent.Spec.Source.GitSource.URL).To(Equal("github.com/foo/bundle-c1"))
+		})
+	})
+})
@@ -32,9 +32,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	appstudioredhatcomv1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
 	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -67,6 +69,10 @@ var cfg *rest.Config
 var k8sClient client.Client
 var testEnv *envtest.Environment
 
+// remoteTestEnv plays the part of a remote cluster Applications are cloned from
+var remoteTestEnv *envtest.Environment
+var remoteK8sClient client.Client
+
 func TestAPIs(t *testing.T) {
 	RegisterFailHandler(Fail)
 
@@ -90,17 +96,19 @@ var _ = BeforeSuite(func() {
 			filepath.Join(build.Default.GOPATH, "pkg", "mod", "github.com", "redhat-appstudio", "integration-service@"+integrationAPIDepVersion, "config", "crd", "bases"),
 		},
 		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "config", "webhook")},
+		},
 	}
 
 	ctx, cancel = context.WithCancel(context.TODO())
 
-	var err error
-	// cfg is defined in this file globally.
-	cfg, err = testEnv.Start()
+	// The types are registered before starting the environment, which points the CRDs of convertible types at
+	// the conversion webhook served by the manager
+	err := appstudioredhatcomv1alpha1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
-	Expect(cfg).NotTo(BeNil())
 
-	err = appstudioredhatcomv1alpha1.AddToScheme(scheme.Scheme)
+	err = appstudioredhatcomv1beta1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
 	err = hasApplicationAPI.AddToScheme(scheme.Scheme)
@@ -111,13 +119,47 @@ var _ = BeforeSuite(func() {
 
 	//+kubebuilder:scaffold:scheme
 
+	// cfg is defined in this file globally.
+	cfg, err = testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(cfg).NotTo(BeNil())
+
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).NotTo(BeNil())
 
-	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{
+	By("bootstrapping the remote test environment")
+
+	remoteTestEnv = &envtest.Environment{
+		CRDDirectoryPaths:     testEnv.CRDDirectoryPaths,
+		ErrorIfCRDPathMissing: true,
+	}
+
+	remoteCfg, err := remoteTestEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(remoteCfg).NotTo(BeNil())
+
+	remoteK8sClient, err = client.New(remoteCfg, client.Options{Scheme: scheme.Scheme})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(remoteK8sClient).NotTo(BeNil())
+
+	webhookInstallOptions := &testEnv.WebhookInstallOptions
+	options := ctrl.Options{
 		Scheme: scheme.Scheme,
-	})
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    webhookInstallOptions.LocalServingHost,
+			Port:    webhookInstallOptions.LocalServingPort,
+			CertDir: webhookInstallOptions.LocalServingCertDir,
+		}),
+	}
+	RestrictSecretCache(&options)
+	k8sManager, err := ctrl.NewManager(cfg, options)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&appstudioredhatcomv1beta1.ApplicationClone{}).SetupWebhookWithManager(k8sManager, &ApplicationCloneValidator{Client: k8sManager.GetClient()})
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&appstudioredhatcomv1alpha1.ApplicationCloneApproval{}).SetupWebhookWithManager(k8sManager, &ApplicationCloneApprovalValidator{Client: k8sManager.GetClient()})
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&ApplicationCloneReconciler{
@@ -126,6 +168,12 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&ApplicationExportReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	go func() {
 		defer GinkgoRecover()
 		err = k8sManager.Start(ctx)
@@ -311,7 +359,15 @@ var _ = Describe("ApplicationClone controller", func() {
 var _ = AfterSuite(func() {
 	var err error
 	By("tearing down the test environment")
-	testEnv.Stop()
+	// BeforeSuite may have failed before setting up the environments
+	if testEnv != nil {
+		err = testEnv.Stop()
+	}
+	if remoteTestEnv != nil {
+		if remoteErr := remoteTestEnv.Stop(); err == nil {
+			err = remoteErr
+		}
+	}
 	Expect(err).NotTo(HaveOccurred())
 })
 
@@ -0,0 +1,71 @@
+#!/usr/bin/env bash
+
+# Generates the typed clientset, listers, informers and apply configurations of the ApplicationClone API
+# into pkg/client. The generators are installed into bin by `make code-generator`.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+# Loading the API must not touch go.mod and go.sum
+export GOFLAGS=-mod=readonly
+
+SCRIPT_ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+LOCALBIN=${LOCALBIN:-${SCRIPT_ROOT}/bin}
+MODULE=github.com/redhat-appstudio/clone-controller
+OUTPUT=${MODULE}/pkg/client
+BOILERPLATE=${SCRIPT_ROOT}/hack/boilerplate.go.txt
+
+# The generators take the directory above the version as the group, and treat a group named "api" as the
+# core group. They are run on a copy of the API in api/appstudio, which is replaced by its real path afterwards.
+VERSIONS=(v1alpha1 v1beta1)
+mkdir -p "${SCRIPT_ROOT}/api/appstudio"
+INPUTS=()
+for version in "${VERSIONS[@]}"; do
+  cp -r "${SCRIPT_ROOT}/api/${version}" "${SCRIPT_ROOT}/api/appstudio/${version}"
+  INPUTS+=("${MODULE}/api/appstudio/${version}")
+done
+APIS=$(IFS=,; echo "${INPUTS[*]}")
+
+# The generators write into a GOPATH layout
+OUTPUT_BASE=$(mktemp -d)
+trap 'rm -rf "${OUTPUT_BASE}" "${SCRIPT_ROOT}/api/appstudio"' EXIT
+
+"${LOCALBIN}/applyconfiguration-gen" \
+  --go-header-file "${BOILERPLATE}" \
+  --input-dirs "${APIS}" \
+  --output-package "${OUTPUT}/applyconfiguration" \
+  --output-base "${OUTPUT_BASE}"
+
+"${LOCALBIN}/client-gen" \
+  --go-header-file "${BOILERPLATE}" \
+  --clientset-name versioned \
+  --input-base "${MODULE}/api" \
+  --input "$(IFS=,; echo "${VERSIONS[*]/#/appstudio/}")" \
+  --apply-configuration-package "${OUTPUT}/applyconfiguration" \
+  --output-package "${OUTPUT}/clientset" \
+  --output-base "${OUTPUT_BASE}"
+
+"${LOCALBIN}/lister-gen" \
+  --go-header-file "${BOILERPLATE}" \
+  --input-dirs "${APIS}" \
+  --output-package "${OUTPUT}/listers" \
+  --output-base "${OUTPUT_BASE}"
+
+"${LOCALBIN}/informer-gen" \
+  --go-header-file "${BOILERPLATE}" \
+  --input-dirs "${APIS}" \
+  --versioned-clientset-package "${OUTPUT}/clientset/versioned" \
+  --listers-package "${OUTPUT}/listers" \
+  --output-package "${OUTPUT}/informers" \
+  --output-base "${OUTPUT_BASE}"
+
+rm -rf "${SCRIPT_ROOT}/pkg/client"
+cp -r "${OUTPUT_BASE}/${OUTPUT}" "${SCRIPT_ROOT}/pkg/client"
+
+# Point the generated code at the real API package. The listers expect a Resource function, which the API package
+# can't declare since Resource is one of its types.
+find "${SCRIPT_ROOT}/pkg/client" -name '*.go' -exec sed -i -E \
+  -e "s|${MODULE}/api/appstudio/(v[a-z0-9]+)\"|${MODULE}/api/\1\"|" \
+  -e 's/(v[a-z0-9]+)\.Resource\("applicationclone"\)/\1.GroupVersion.WithResource("applicationclone").GroupResource()/' {} +
+gofmt -w "${SCRIPT_ROOT}/pkg/client"
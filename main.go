@@ -18,23 +18,31 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
 
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
 	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	appstudioredhatcomv1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
 	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
 
 	"github.com/redhat-appstudio/clone-controller/controllers"
+	"github.com/redhat-appstudio/clone-controller/pkg/clone"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -47,6 +55,8 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(appstudioredhatcomv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(appstudioredhatcomv1beta1.AddToScheme(scheme))
+	utilruntime.Must(hasApplicationAPI.AddToScheme(scheme))
 	utilruntime.Must(integrationtestapi.AddToScheme(scheme))
 
 	//+kubebuilder:scaffold:scheme
@@ -56,11 +66,36 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var imageVisibility string
+	var initialBuild bool
+	buildAnnotations := annotationsFlag{}
+	var unstructuredCloning bool
+	var discoverVersions bool
+	var sanitizationPolicy string
+	var requireGrants bool
+	extraResourceKinds := &kindsFlag{}
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&imageVisibility, "default-image-visibility", string(appstudioredhatcomv1alpha1.ImageVisibilityPrivate),
+		"The visibility of the images of the Components cloned from source, Public or Private, unless set by the ApplicationClone.")
+	flag.BoolVar(&initialBuild, "default-initial-build", false,
+		"Trigger a build of the Components cloned from source once created, unless set by the ApplicationClone.")
+	flag.Var(buildAnnotations, "default-build-annotation",
+		"A key=value annotation added to the Components cloned from source for the build service. Can be repeated.")
+	flag.BoolVar(&unstructuredCloning, "unstructured-cloning", false,
+		"Clone resources as unstructured objects, keeping the fields that the controller's API types lack.")
+	flag.BoolVar(&discoverVersions, "discover-api-versions", false,
+		"With --unstructured-cloning, clone resources in the API versions preferred by the source cluster.")
+	flag.StringVar(&sanitizationPolicy, "sanitization-policy", "",
+		"A YAML file holding the list of sanitization rules applied to the environment variables of every clone.")
+	flag.BoolVar(&requireGrants, "require-clone-grants", true,
+		"Refuse to clone or export an Application into another namespace unless a CloneGrant of its namespace allows it. "+
+			"Set to false to let any namespace clone the Applications of any other.")
+	flag.Var(extraResourceKinds, "extra-resource-kind",
+		"A Kind.group that extraResources may select on top of ConfigMaps, Services and Routes, e.g. Widget.example.com. Can be repeated.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -69,7 +104,23 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	build := &appstudioredhatcomv1alpha1.BuildSettings{
+		ImageVisibility: appstudioredhatcomv1alpha1.ImageVisibility(imageVisibility),
+		InitialBuild:    &initialBuild,
+		Annotations:     buildAnnotations,
+	}
+	if build.ImageVisibility != appstudioredhatcomv1alpha1.ImageVisibilityPublic && build.ImageVisibility != appstudioredhatcomv1alpha1.ImageVisibilityPrivate {
+		setupLog.Error(fmt.Errorf("unknown image visibility %q", imageVisibility), "invalid --default-image-visibility")
+		os.Exit(1)
+	}
+
+	sanitization, err := loadSanitizationPolicy(sanitizationPolicy)
+	if err != nil {
+		setupLog.Error(err, "invalid --sanitization-policy")
+		os.Exit(1)
+	}
+
+	options := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
@@ -87,19 +138,48 @@ func main() {
 		// if you are doing or is intended to do any operation such as perform cleanups
 		// after the manager stops then its usage might be unsafe.
 		// LeaderElectionReleaseOnCancel: true,
-	})
+	}
+	controllers.RestrictSecretCache(&options)
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), options)
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.ApplicationCloneReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		Build:              build,
+		Unstructured:       unstructuredCloning,
+		DiscoverVersions:   discoverVersions,
+		Sanitization:       sanitization,
+		RequireGrants:      requireGrants,
+		ExtraResourceKinds: *extraResourceKinds,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ApplicationClone")
 		os.Exit(1)
 	}
+	if err = (&controllers.ApplicationExportReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		RequireGrants: requireGrants,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApplicationExport")
+		os.Exit(1)
+	}
+	// The webhooks need a serving certificate; it can be disabled when running the controller locally
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		validator := &controllers.ApplicationCloneValidator{Client: mgr.GetClient()}
+		if err = (&appstudioredhatcomv1beta1.ApplicationClone{}).SetupWebhookWithManager(mgr, validator); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ApplicationClone")
+			os.Exit(1)
+		}
+		approvalValidator := &controllers.ApplicationCloneApprovalValidator{Client: mgr.GetClient()}
+		if err = (&appstudioredhatcomv1alpha1.ApplicationCloneApproval{}).SetupWebhookWithManager(mgr, approvalValidator); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ApplicationCloneApproval")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -117,3 +197,63 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// loadSanitizationPolicy reads the sanitization rules of the file, if any
+func loadSanitizationPolicy(path string) ([]appstudioredhatcomv1alpha1.SanitizationRule, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading sanitization policy: %w", err)
+	}
+	var rules []appstudioredhatcomv1alpha1.SanitizationRule
+	if err := yaml.UnmarshalStrict(data, &rules); err != nil {
+		return nil, fmt.Errorf("error parsing sanitization policy: %w", err)
+	}
+	return rules, clone.ValidateSanitizationRules(rules)
+}
+
+// annotationsFlag collects the key=value annotations of a repeated flag
+type annotationsFlag map[string]string
+
+func (f annotationsFlag) String() string {
+	var annotations []string
+	for k, v := range f {
+		annotations = append(annotations, k+"="+v)
+	}
+	sort.Strings(annotations)
+	return strings.Join(annotations, ",")
+}
+
+func (f annotationsFlag) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("annotation %q isn't of the form key=value", value)
+	}
+	f[k] = v
+	return nil
+}
+
+// kindsFlag collects the Kind.group kinds of a repeated flag
+type kindsFlag []schema.GroupKind
+
+func (f *kindsFlag) String() string {
+	var kinds []string
+	for _, gk := range *f {
+		kinds = append(kinds, gk.String())
+	}
+	return strings.Join(kinds, ",")
+}
+
+func (f *kindsFlag) Set(value string) error {
+	gk := schema.ParseGroupKind(value)
+	if gk.Kind == "" {
+		return fmt.Errorf("kind %q isn't of the form Kind.group", value)
+	}
+	if gk == (schema.GroupKind{Kind: "Secret"}) {
+		return fmt.Errorf("Secrets can't be cloned as extra resources")
+	}
+	*f = append(*f, gk)
+	return nil
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundle defines the portable, cluster-neutral format Applications are exported to. A bundle
+// holds the Application with its Components, IntegrationTestScenarios and Secrets, stripped of the
+// fields that are managed by the server or tied to the namespace they were read from.
+package bundle
+
+import (
+	"fmt"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// APIVersion is the version of the bundle format written by this package
+	APIVersion = "bundle.appstudio.redhat.com/v1alpha1"
+
+	// Kind of the bundle document
+	Kind = "ApplicationBundle"
+
+	// FileName is the name the bundle is stored under, e.g. as a ConfigMap key
+	FileName = "bundle.yaml"
+
+	// RedactedAnnotation marks Secrets whose values were left out of the bundle
+	RedactedAnnotation = "bundle.appstudio.redhat.com/redacted"
+)
+
+// lastAppliedAnnotation is set by kubectl and refers to the source object
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Bundle is an exported Application
+type Bundle struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// SourceNamespace is the namespace the Application was exported from
+	SourceNamespace string `json:"sourceNamespace,omitempty"`
+
+	Application              hasApplicationAPI.Application                `json:"application"`
+	Components               []hasApplicationAPI.Component                `json:"components,omitempty"`
+	IntegrationTestScenarios []integrationtestapi.IntegrationTestScenario `json:"integrationTestScenarios,omitempty"`
+	Secrets                  []corev1.Secret                              `json:"secrets,omitempty"`
+}
+
+// New returns a bundle for the given Application
+func New(application *hasApplicationAPI.Application) *Bundle {
+	return &Bundle{
+		APIVersion:      APIVersion,
+		Kind:            Kind,
+		SourceNamespace: application.Namespace,
+		Application: hasApplicationAPI.Application{
+			TypeMeta:   metav1.TypeMeta{APIVersion: hasApplicationAPI.GroupVersion.String(), Kind: "Application"},
+			ObjectMeta: neutralObjectMeta(application.ObjectMeta),
+			Spec:       *application.Spec.DeepCopy(),
+		},
+	}
+}
+
+// AddComponent adds a copy of the Component, without its status, to the bundle
+func (b *Bundle) AddComponent(component *hasApplicationAPI.Component) {
+	b.Components = append(b.Components, hasApplicationAPI.Component{
+		TypeMeta:   metav1.TypeMeta{APIVersion: hasApplicationAPI.GroupVersion.String(), Kind: "Component"},
+		ObjectMeta: neutralObjectMeta(component.ObjectMeta),
+		Spec:       *component.Spec.DeepCopy(),
+	})
+}
+
+// AddIntegrationTestScenario adds a copy of the IntegrationTestScenario, without its status, to the bundle
+func (b *Bundle) AddIntegrationTestScenario(integrationTest *integrationtestapi.IntegrationTestScenario) {
+	b.IntegrationTestScenarios = append(b.IntegrationTestScenarios, integrationtestapi.IntegrationTestScenario{
+		TypeMeta:   metav1.TypeMeta{APIVersion: integrationtestapi.GroupVersion.String(), Kind: "IntegrationTestScenario"},
+		ObjectMeta: neutralObjectMeta(integrationTest.ObjectMeta),
+		Spec:       *integrationTest.Spec.DeepCopy(),
+	})
+}
+
+// AddSecret adds a copy of the Secret to the bundle. When redact is set, only the keys of the Secret
+// are kept and the Secret is annotated with RedactedAnnotation; otherwise the annotation is dropped.
+func (b *Bundle) AddSecret(secret *corev1.Secret, redact bool) {
+	exported := corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: neutralObjectMeta(secret.ObjectMeta),
+		Type:       secret.Type,
+		Data:       map[string][]byte{},
+	}
+
+	for key, value := range secret.Data {
+		exported.Data[key] = append([]byte(nil), value...)
+	}
+	for key, value := range secret.StringData {
+		exported.Data[key] = []byte(value)
+	}
+
+	if redact {
+		for key := range exported.Data {
+			exported.Data[key] = []byte{}
+		}
+		if exported.Annotations == nil {
+			exported.Annotations = map[string]string{}
+		}
+		exported.Annotations[RedactedAnnotation] = "true"
+	} else {
+		delete(exported.Annotations, RedactedAnnotation)
+	}
+
+	b.Secrets = append(b.Secrets, exported)
+}
+
+// Redacted tells whether the object is a Secret of a bundle whose values were left out
+func Redacted(obj client.Object) bool {
+	return obj.GetObjectKind().GroupVersionKind().Kind == "Secret" && obj.GetAnnotations()[RedactedAnnotation] == "true"
+}
+
+// Objects returns copies of the objects of the bundle: the Application, its Components, IntegrationTestScenarios
+// and Secrets, in that order
+func (b *Bundle) Objects() []client.Object {
+	objects := []client.Object{b.Application.DeepCopy()}
+	for i := range b.Components {
+		objects = append(objects, b.Components[i].DeepCopy())
+	}
+	for i := range b.IntegrationTestScenarios {
+		objects = append(objects, b.IntegrationTestScenarios[i].DeepCopy())
+	}
+	for i := range b.Secrets {
+		objects = append(objects, b.Secrets[i].DeepCopy())
+	}
+	return objects
+}
+
+// Marshal serializes the bundle to YAML
+func (b *Bundle) Marshal() ([]byte, error) {
+	return yaml.Marshal(b)
+}
+
+// Unmarshal parses a bundle written by Marshal
+func Unmarshal(data []byte) (*Bundle, error) {
+	b := &Bundle{}
+	if err := yaml.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("error parsing bundle: %w", err)
+	}
+
+	if b.Kind != Kind {
+		return nil, fmt.Errorf("unexpected bundle kind %q", b.Kind)
+	}
+	if b.APIVersion != APIVersion {
+		return nil, fmt.Errorf("unsupported bundle version %q", b.APIVersion)
+	}
+
+	return b, nil
+}
+
+// neutralObjectMeta keeps the parts of the metadata that are meaningful outside of the source namespace
+func neutralObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	neutral := metav1.ObjectMeta{
+		Name: meta.Name,
+	}
+
+	if len(meta.Labels) > 0 {
+		neutral.Labels = map[string]string{}
+		for k, v := range meta.Labels {
+			neutral.Labels[k] = v
+		}
+	}
+
+	for k, v := range meta.Annotations {
+		if k == lastAppliedAnnotation {
+			continue
+		}
+		if neutral.Annotations == nil {
+			neutral.Annotations = map[string]string{}
+		}
+		neutral.Annotations[k] = v
+	}
+
+	return neutral
+}
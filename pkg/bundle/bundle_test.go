@@ -0,0 +1,140 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// sourceObjectMeta carries the server-managed and namespace-specific fields a bundle must not contain
+func sourceObjectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         "foo",
+		UID:               types.UID("6f0a1e4c-3c1b-4a38-9f65-0d6c1c3b0f00"),
+		ResourceVersion:   "4242",
+		Generation:        3,
+		CreationTimestamp: metav1.Now(),
+		Labels:            map[string]string{"team": "billing"},
+		Annotations: map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			"description": "kept",
+		},
+		OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "1"}},
+		Finalizers:      []string{"example.com/finalizer"},
+	}
+}
+
+var _ = Describe("Bundle", func() {
+
+	It("Should strip server-managed fields and status", func() {
+		b := New(&hasApplicationAPI.Application{
+			ObjectMeta: sourceObjectMeta("billing-app"),
+			Spec:       hasApplicationAPI.ApplicationSpec{DisplayName: "Billing"},
+			Status:     hasApplicationAPI.ApplicationStatus{Devfile: "schemaVersion: 2.2.0"},
+		})
+		b.AddComponent(&hasApplicationAPI.Component{
+			ObjectMeta: sourceObjectMeta("c1"),
+			Spec:       hasApplicationAPI.ComponentSpec{Application: "billing-app", ContainerImage: "quay.io/foo/c1"},
+			Status:     hasApplicationAPI.ComponentStatus{ContainerImage: "quay.io/foo/c1@sha256:abc"},
+		})
+		b.AddIntegrationTestScenario(&integrationtestapi.IntegrationTestScenario{
+			ObjectMeta: sourceObjectMeta("e2e"),
+			Spec:       integrationtestapi.IntegrationTestScenarioSpec{Application: "billing-app"},
+		})
+
+		Expect(b.SourceNamespace).To(Equal("foo"))
+		Expect(b.Application.ObjectMeta).To(Equal(metav1.ObjectMeta{
+			Name:        "billing-app",
+			Labels:      map[string]string{"team": "billing"},
+			Annotations: map[string]string{"description": "kept"},
+		}))
+		Expect(b.Application.Kind).To(Equal("Application"))
+		Expect(b.Application.Status).To(BeZero())
+		Expect(b.Components).To(HaveLen(1))
+		Expect(b.Components[0].Namespace).To(BeEmpty())
+		Expect(b.Components[0].Status).To(BeZero())
+		Expect(b.Components[0].Spec.ContainerImage).To(Equal("quay.io/foo/c1"))
+		Expect(b.IntegrationTestScenarios).To(HaveLen(1))
+		Expect(b.IntegrationTestScenarios[0].UID).To(BeEmpty())
+	})
+
+	It("Should redact Secret values", func() {
+		b := New(&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "billing-app"}})
+		secret := &corev1.Secret{
+			ObjectMeta: sourceObjectMeta("git-token"),
+			Type:       corev1.SecretTypeBasicAuth,
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+			StringData: map[string]string{"username": "billing"},
+		}
+		b.AddSecret(secret, true)
+		b.AddSecret(secret, false)
+
+		Expect(b.Secrets[0].Type).To(Equal(corev1.SecretTypeBasicAuth))
+		Expect(b.Secrets[0].Data).To(Equal(map[string][]byte{"password": {}, "username": {}}))
+		Expect(b.Secrets[0].Annotations).To(HaveKeyWithValue(RedactedAnnotation, "true"))
+		Expect(b.Secrets[1].Data).To(Equal(map[string][]byte{"password": []byte("hunter2"), "username": []byte("billing")}))
+		Expect(b.Secrets[1].Annotations).NotTo(HaveKey(RedactedAnnotation))
+		Expect(secret.Annotations).NotTo(HaveKey(RedactedAnnotation))
+	})
+
+	It("Should only keep the RedactedAnnotation of redacted Secrets", func() {
+		b := New(&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "billing-app"}})
+		meta := sourceObjectMeta("git-token")
+		meta.Annotations = map[string]string{RedactedAnnotation: "true"}
+		b.AddSecret(&corev1.Secret{ObjectMeta: meta, Data: map[string][]byte{"password": []byte("hunter2")}}, false)
+
+		Expect(b.Secrets[0].Annotations).NotTo(HaveKey(RedactedAnnotation))
+		Expect(Redacted(&b.Secrets[0])).To(BeFalse())
+	})
+
+	It("Should round-trip through Marshal and Unmarshal", func() {
+		b := New(&hasApplicationAPI.Application{
+			ObjectMeta: metav1.ObjectMeta{Name: "billing-app"},
+			Spec:       hasApplicationAPI.ApplicationSpec{DisplayName: "Billing"},
+		})
+		b.AddComponent(&hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1"},
+			Spec:       hasApplicationAPI.ComponentSpec{Application: "billing-app", ComponentName: "c1"},
+		})
+
+		data, err := b.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("apiVersion: " + APIVersion))
+
+		parsed, err := Unmarshal(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed.Application.Spec).To(Equal(b.Application.Spec))
+		Expect(parsed.Components).To(HaveLen(1))
+		Expect(parsed.Components[0].Spec).To(Equal(b.Components[0].Spec))
+	})
+
+	It("Should reject unknown bundle versions", func() {
+		_, err := Unmarshal([]byte("apiVersion: bundle.appstudio.redhat.com/v9\nkind: ApplicationBundle\n"))
+		Expect(err).To(MatchError(ContainSubstring("unsupported bundle version")))
+
+		_, err = Unmarshal([]byte("apiVersion: v1\nkind: ConfigMap\n"))
+		Expect(err).To(MatchError(ContainSubstring("unexpected bundle kind")))
+	})
+})
@@ -0,0 +1,136 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// MediaType is the media type of the layer holding the bundle in an OCI artifact
+	MediaType types.MediaType = "application/vnd.appstudio.application-bundle.v1alpha1+yaml"
+
+	// ConfigMediaType is the config media type identifying OCI artifacts that hold a bundle
+	ConfigMediaType types.MediaType = "application/vnd.appstudio.application-bundle.config.v1alpha1+json"
+)
+
+// MaxSize is the size of the largest bundle layer Pull reads, in bytes. Bundles hold resource definitions, which
+// are far smaller; the limit keeps a large artifact from exhausting the memory of the reader.
+const MaxSize = 16 << 20
+
+// titleAnnotation names the bundle layer, so that tools like oras pull it to a file
+const titleAnnotation = "org.opencontainers.image.title"
+
+// Push pushes the bundle as an OCI artifact to the given tag, returning the digest of the artifact.
+func Push(ctx context.Context, reference string, b *Bundle, opts ...remote.Option) (string, error) {
+	tag, err := name.NewTag(reference)
+	if err != nil {
+		return "", fmt.Errorf("error parsing reference %s: %w", reference, err)
+	}
+
+	data, err := b.Marshal()
+	if err != nil {
+		return "", fmt.Errorf("error serializing bundle: %w", err)
+	}
+
+	artifact := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	artifact = mutate.ConfigMediaType(artifact, ConfigMediaType)
+	artifact, err = mutate.Append(artifact, mutate.Addendum{
+		Layer:       static.NewLayer(data, MediaType),
+		Annotations: map[string]string{titleAnnotation: FileName},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error building artifact: %w", err)
+	}
+
+	err = remote.Write(tag, artifact, append(opts, remote.WithContext(ctx))...)
+	if err != nil {
+		return "", fmt.Errorf("error pushing bundle to %s: %w", reference, err)
+	}
+
+	digest, err := artifact.Digest()
+	if err != nil {
+		return "", fmt.Errorf("error computing artifact digest: %w", err)
+	}
+
+	return digest.String(), nil
+}
+
+// Pull pulls the bundle from the OCI artifact at the given tag or digest reference. Bundle layers larger than
+// MaxSize are refused.
+func Pull(ctx context.Context, reference string, opts ...remote.Option) (*Bundle, error) {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing reference %s: %w", reference, err)
+	}
+
+	artifact, err := remote.Image(ref, append(opts, remote.WithContext(ctx))...)
+	if err != nil {
+		return nil, fmt.Errorf("error pulling bundle from %s: %w", reference, err)
+	}
+
+	manifest, err := artifact.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest of %s: %w", reference, err)
+	}
+	if manifest.Config.MediaType != ConfigMediaType {
+		return nil, fmt.Errorf("%s is not an application bundle: unexpected config media type %q", reference, manifest.Config.MediaType)
+	}
+
+	for _, descriptor := range manifest.Layers {
+		if descriptor.MediaType != MediaType {
+			continue
+		}
+
+		if descriptor.Size > MaxSize {
+			return nil, fmt.Errorf("bundle layer of %s is too large: %d bytes, more than %d", reference, descriptor.Size, MaxSize)
+		}
+
+		layer, err := artifact.LayerByDigest(descriptor.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("error reading bundle layer of %s: %w", reference, err)
+		}
+		// the bundle is stored as is, the compressed form of the layer is the bundle itself
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, fmt.Errorf("error reading bundle layer of %s: %w", reference, err)
+		}
+		defer rc.Close()
+
+		// the registry may serve more than the manifest tells
+		data, err := io.ReadAll(io.LimitReader(rc, MaxSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("error reading bundle layer of %s: %w", reference, err)
+		}
+		if len(data) > MaxSize {
+			return nil, fmt.Errorf("bundle layer of %s is too large: more than %d bytes", reference, MaxSize)
+		}
+
+		return Unmarshal(data)
+	}
+
+	return nil, fmt.Errorf("%s has no %s layer", reference, MediaType)
+}
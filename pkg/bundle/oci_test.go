@@ -0,0 +1,104 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("OCI artifacts", func() {
+
+	var server *httptest.Server
+	var repository string
+
+	BeforeEach(func() {
+		server = httptest.NewServer(registry.New())
+		repository = strings.TrimPrefix(server.URL, "http://") + "/templates/billing-app"
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Should pull a pushed bundle by tag and by digest", func() {
+		b := New(&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "billing-app"}})
+		b.AddComponent(&hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1"},
+			Spec:       hasApplicationAPI.ComponentSpec{Application: "billing-app"},
+		})
+
+		digest, err := Push(context.Background(), repository+":v1", b)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(digest).To(HavePrefix("sha256:"))
+
+		for _, reference := range []string{repository + ":v1", repository + "@" + digest} {
+			pulled, err := Pull(context.Background(), reference)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pulled.Application.Name).To(Equal("billing-app"))
+			Expect(pulled.Components).To(HaveLen(1))
+		}
+	})
+
+	It("Should not pull artifacts that aren't bundles", func() {
+		image, err := random.Image(64, 1)
+		Expect(err).NotTo(HaveOccurred())
+		tag, err := name.NewTag(repository + ":image")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remote.Write(tag, image)).To(Succeed())
+
+		_, err = Pull(context.Background(), repository+":image")
+		Expect(err).To(MatchError(ContainSubstring("is not an application bundle")))
+
+		_, err = Pull(context.Background(), repository+":missing")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should not pull bundles larger than MaxSize", func() {
+		artifact := mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+		artifact = mutate.ConfigMediaType(artifact, ConfigMediaType)
+		artifact, err := mutate.AppendLayers(artifact, static.NewLayer(make([]byte, MaxSize+1), MediaType))
+		Expect(err).NotTo(HaveOccurred())
+		tag, err := name.NewTag(repository + ":large")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(remote.Write(tag, artifact)).To(Succeed())
+
+		_, err = Pull(context.Background(), repository+":large")
+		Expect(err).To(MatchError(ContainSubstring("is too large")))
+	})
+
+	It("Should only push to tags", func() {
+		b := New(&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "billing-app"}})
+		_, err := Push(context.Background(), repository+"@sha256:"+strings.Repeat("0", 64), b)
+		Expect(err).To(MatchError(ContainSubstring("error parsing reference")))
+	})
+})
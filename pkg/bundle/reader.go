@@ -0,0 +1,129 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Reader serves the objects of a bundle as if they were stored in a namespace, so that the code reading
+// Applications from a live namespace can read them from a bundle as well. Kinds the bundle doesn't hold
+// are listed as empty and reported as not found.
+type Reader struct {
+	scheme          *runtime.Scheme
+	namespace       string
+	sourceNamespace string
+	objects         []client.Object
+}
+
+var _ client.Reader = &Reader{}
+
+// Reader returns a Reader serving the bundle's objects in the given namespace. The scheme must know
+// the kinds of the bundle's objects.
+func (b *Bundle) Reader(scheme *runtime.Scheme, namespace string) *Reader {
+	r := &Reader{scheme: scheme, namespace: namespace, sourceNamespace: b.SourceNamespace}
+	for _, obj := range b.Objects() {
+		r.add(obj)
+	}
+	return r
+}
+
+// SourceNamespace returns the namespace the bundle's Application was exported from, empty if the bundle doesn't
+// record it
+func (r *Reader) SourceNamespace() string {
+	return r.sourceNamespace
+}
+
+func (r *Reader) add(obj client.Object) {
+	obj.SetNamespace(r.namespace)
+	r.objects = append(r.objects, obj)
+}
+
+// Get implements client.Reader
+func (r *Reader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	gvk, err := apiutil.GVKForObject(obj, r.scheme)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range r.objects {
+		if o.GetNamespace() != key.Namespace || o.GetName() != key.Name {
+			continue
+		}
+		if !r.isKind(o, gvk) {
+			continue
+		}
+		return copyInto(o, obj)
+	}
+
+	// the bundle only holds kinds whose resource name is their plural, e.g. components or secrets
+	resource, _ := meta.UnsafeGuessKindToResource(gvk)
+	return errors.NewNotFound(resource.GroupResource(), key.Name)
+}
+
+// List implements client.Reader. Only the namespace and label selector options are supported.
+func (r *Reader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOptions := &client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+
+	gvk, err := apiutil.GVKForObject(list, r.scheme)
+	if err != nil {
+		return err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+
+	var items []runtime.Object
+	for _, o := range r.objects {
+		if listOptions.Namespace != "" && o.GetNamespace() != listOptions.Namespace {
+			continue
+		}
+		if listOptions.LabelSelector != nil && !listOptions.LabelSelector.Matches(labels.Set(o.GetLabels())) {
+			continue
+		}
+		if !r.isKind(o, gvk) {
+			continue
+		}
+		items = append(items, o.DeepCopyObject())
+	}
+
+	return meta.SetList(list, items)
+}
+
+func (r *Reader) isKind(obj client.Object, gvk schema.GroupVersionKind) bool {
+	objGVK, err := apiutil.GVKForObject(obj, r.scheme)
+	return err == nil && objGVK.GroupKind() == gvk.GroupKind()
+}
+
+// copyInto copies src into dst, which are of the same kind
+func copyInto(src, dst client.Object) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return fmt.Errorf("error copying %s: %w", src.GetName(), err)
+	}
+	return json.Unmarshal(data, dst)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Reader", func() {
+
+	var reader *Reader
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(scheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(scheme)).To(Succeed())
+
+		b := New(&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "billing-app", Namespace: "foo"}})
+		b.AddComponent(&hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1", Labels: map[string]string{"tier": "frontend"}},
+			Spec:       hasApplicationAPI.ComponentSpec{Application: "billing-app", ContainerImage: "quay.io/foo/c1"},
+		})
+		b.AddComponent(&hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "c2"},
+			Spec:       hasApplicationAPI.ComponentSpec{Application: "billing-app"},
+		})
+		b.AddSecret(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "git-token"}}, true)
+
+		reader = b.Reader(scheme, "bar")
+	})
+
+	It("Should get objects in the namespace", func() {
+		component := &hasApplicationAPI.Component{}
+		Expect(reader.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "c1"}, component)).To(Succeed())
+		Expect(component.Namespace).To(Equal("bar"))
+		Expect(component.Spec.ContainerImage).To(Equal("quay.io/foo/c1"))
+		Expect(reader.SourceNamespace()).To(Equal("foo"))
+
+		err := reader.Get(context.Background(), types.NamespacedName{Namespace: "foo", Name: "c1"}, component)
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(err).To(MatchError(`components.appstudio.redhat.com "c1" not found`))
+		Expect(err.(errors.APIStatus).Status().Details.Group).To(Equal("appstudio.redhat.com"))
+		Expect(err.(errors.APIStatus).Status().Details.Kind).To(Equal("components"))
+
+		err = reader.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "c1"}, &corev1.Secret{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(err).To(MatchError(`secrets "c1" not found`))
+	})
+
+	It("Should list objects by kind, namespace and labels", func() {
+		componentList := &hasApplicationAPI.ComponentList{}
+		Expect(reader.List(context.Background(), componentList, &client.ListOptions{Namespace: "bar"})).To(Succeed())
+		Expect(componentList.Items).To(HaveLen(2))
+
+		Expect(reader.List(context.Background(), componentList, &client.ListOptions{
+			Namespace:     "bar",
+			LabelSelector: labels.SelectorFromSet(labels.Set{"tier": "frontend"}),
+		})).To(Succeed())
+		Expect(componentList.Items).To(HaveLen(1))
+		Expect(componentList.Items[0].Name).To(Equal("c1"))
+
+		Expect(reader.List(context.Background(), componentList, &client.ListOptions{Namespace: "foo"})).To(Succeed())
+		Expect(componentList.Items).To(BeEmpty())
+
+		environmentList := &hasApplicationAPI.EnvironmentList{}
+		Expect(reader.List(context.Background(), environmentList)).To(Succeed())
+		Expect(environmentList.Items).To(BeEmpty())
+	})
+
+	It("Should not hand out its own objects", func() {
+		componentList := &hasApplicationAPI.ComponentList{}
+		Expect(reader.List(context.Background(), componentList)).To(Succeed())
+		componentList.Items[0].Spec.ContainerImage = "changed"
+
+		component := &hasApplicationAPI.Component{}
+		Expect(reader.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: componentList.Items[0].Name}, component)).To(Succeed())
+		Expect(component.Spec.ContainerImage).NotTo(Equal("changed"))
+	})
+})
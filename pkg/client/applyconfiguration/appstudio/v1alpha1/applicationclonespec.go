@@ -0,0 +1,207 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	appstudiov1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+// ApplicationCloneSpecApplyConfiguration represents an declarative configuration of the ApplicationCloneSpec type for use
+// with apply.
+type ApplicationCloneSpecApplyConfiguration struct {
+	From                        *FromApplyConfiguration                              `json:"from,omitempty"`
+	ServiceAccountName          *string                                              `json:"serviceAccountName,omitempty"`
+	ComponentSources            []ComponentSourceApplyConfiguration                  `json:"componentSources,omitempty"`
+	DefaultMode                 *appstudiov1alpha1.ComponentMode                     `json:"defaultMode,omitempty"`
+	Snapshot                    *string                                              `json:"snapshot,omitempty"`
+	Build                       *BuildSettingsApplyConfiguration                     `json:"build,omitempty"`
+	IntegrationTests            *IntegrationTestSelectionApplyConfiguration          `json:"integrationTests,omitempty"`
+	IntegrationTestOverrides    []IntegrationTestOverrideApplyConfiguration          `json:"integrationTestOverrides,omitempty"`
+	Environments                *EnvironmentCloningApplyConfiguration                `json:"environments,omitempty"`
+	SnapshotEnvironmentBindings *SnapshotEnvironmentBindingCloningApplyConfiguration `json:"snapshotEnvironmentBindings,omitempty"`
+	ExtraResources              []ExtraResourceApplyConfiguration                    `json:"extraResources,omitempty"`
+	ConfigMapOverrides          []ConfigMapOverrideApplyConfiguration                `json:"configMapOverrides,omitempty"`
+	Secrets                     []SecretCloningApplyConfiguration                    `json:"secrets,omitempty"`
+	DefaultSecretStrategy       *appstudiov1alpha1.SecretStrategy                    `json:"defaultSecretStrategy,omitempty"`
+	Sanitization                []SanitizationRuleApplyConfiguration                 `json:"sanitization,omitempty"`
+	GitOps                      *GitOpsTargetApplyConfiguration                      `json:"gitOps,omitempty"`
+}
+
+// ApplicationCloneSpecApplyConfiguration constructs an declarative configuration of the ApplicationCloneSpec type for use with
+// apply.
+func ApplicationCloneSpec() *ApplicationCloneSpecApplyConfiguration {
+	return &ApplicationCloneSpecApplyConfiguration{}
+}
+
+// WithFrom sets the From field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the From field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithFrom(value *FromApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	b.From = value
+	return b
+}
+
+// WithServiceAccountName sets the ServiceAccountName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceAccountName field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithServiceAccountName(value string) *ApplicationCloneSpecApplyConfiguration {
+	b.ServiceAccountName = &value
+	return b
+}
+
+// WithComponentSources adds the given value to the ComponentSources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ComponentSources field.
+func (b *ApplicationCloneSpecApplyConfiguration) WithComponentSources(values ...*ComponentSourceApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithComponentSources")
+		}
+		b.ComponentSources = append(b.ComponentSources, *values[i])
+	}
+	return b
+}
+
+// WithDefaultMode sets the DefaultMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultMode field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithDefaultMode(value appstudiov1alpha1.ComponentMode) *ApplicationCloneSpecApplyConfiguration {
+	b.DefaultMode = &value
+	return b
+}
+
+// WithSnapshot sets the Snapshot field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Snapshot field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithSnapshot(value string) *ApplicationCloneSpecApplyConfiguration {
+	b.Snapshot = &value
+	return b
+}
+
+// WithBuild sets the Build field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Build field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithBuild(value *BuildSettingsApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	b.Build = value
+	return b
+}
+
+// WithIntegrationTests sets the IntegrationTests field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IntegrationTests field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithIntegrationTests(value *IntegrationTestSelectionApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	b.IntegrationTests = value
+	return b
+}
+
+// WithIntegrationTestOverrides adds the given value to the IntegrationTestOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IntegrationTestOverrides field.
+func (b *ApplicationCloneSpecApplyConfiguration) WithIntegrationTestOverrides(values ...*IntegrationTestOverrideApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithIntegrationTestOverrides")
+		}
+		b.IntegrationTestOverrides = append(b.IntegrationTestOverrides, *values[i])
+	}
+	return b
+}
+
+// WithEnvironments sets the Environments field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Environments field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithEnvironments(value *EnvironmentCloningApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	b.Environments = value
+	return b
+}
+
+// WithSnapshotEnvironmentBindings sets the SnapshotEnvironmentBindings field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SnapshotEnvironmentBindings field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithSnapshotEnvironmentBindings(value *SnapshotEnvironmentBindingCloningApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	b.SnapshotEnvironmentBindings = value
+	return b
+}
+
+// WithExtraResources adds the given value to the ExtraResources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExtraResources field.
+func (b *ApplicationCloneSpecApplyConfiguration) WithExtraResources(values ...*ExtraResourceApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithExtraResources")
+		}
+		b.ExtraResources = append(b.ExtraResources, *values[i])
+	}
+	return b
+}
+
+// WithConfigMapOverrides adds the given value to the ConfigMapOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConfigMapOverrides field.
+func (b *ApplicationCloneSpecApplyConfiguration) WithConfigMapOverrides(values ...*ConfigMapOverrideApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConfigMapOverrides")
+		}
+		b.ConfigMapOverrides = append(b.ConfigMapOverrides, *values[i])
+	}
+	return b
+}
+
+// WithSecrets adds the given value to the Secrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Secrets field.
+func (b *ApplicationCloneSpecApplyConfiguration) WithSecrets(values ...*SecretCloningApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSecrets")
+		}
+		b.Secrets = append(b.Secrets, *values[i])
+	}
+	return b
+}
+
+// WithDefaultSecretStrategy sets the DefaultSecretStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultSecretStrategy field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithDefaultSecretStrategy(value appstudiov1alpha1.SecretStrategy) *ApplicationCloneSpecApplyConfiguration {
+	b.DefaultSecretStrategy = &value
+	return b
+}
+
+// WithSanitization adds the given value to the Sanitization field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Sanitization field.
+func (b *ApplicationCloneSpecApplyConfiguration) WithSanitization(values ...*SanitizationRuleApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSanitization")
+		}
+		b.Sanitization = append(b.Sanitization, *values[i])
+	}
+	return b
+}
+
+// WithGitOps sets the GitOps field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GitOps field is set to the value of the last call.
+func (b *ApplicationCloneSpecApplyConfiguration) WithGitOps(value *GitOpsTargetApplyConfiguration) *ApplicationCloneSpecApplyConfiguration {
+	b.GitOps = value
+	return b
+}
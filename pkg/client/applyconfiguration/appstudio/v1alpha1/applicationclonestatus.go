@@ -0,0 +1,136 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ApplicationCloneStatusApplyConfiguration represents an declarative configuration of the ApplicationCloneStatus type for use
+// with apply.
+type ApplicationCloneStatusApplyConfiguration struct {
+	Resources             []ResourceApplyConfiguration        `json:"resources,omitempty"`
+	Error                 *string                             `json:"error,omitempty"`
+	LastSuccessfulAttempt *string                             `json:"lastSuccessfulAttempt,omitempty"`
+	LastAttempt           *string                             `json:"lastAttempt,omitempty"`
+	Commit                *string                             `json:"commit,omitempty"`
+	PendingSecrets        []string                            `json:"pendingSecrets,omitempty"`
+	Sanitized             []SanitizedFieldApplyConfiguration  `json:"sanitized,omitempty"`
+	PolicyViolations      []PolicyViolationApplyConfiguration `json:"policyViolations,omitempty"`
+	PendingApproval       *bool                               `json:"pendingApproval,omitempty"`
+	Approval              *ApprovalRecordApplyConfiguration   `json:"approval,omitempty"`
+}
+
+// ApplicationCloneStatusApplyConfiguration constructs an declarative configuration of the ApplicationCloneStatus type for use with
+// apply.
+func ApplicationCloneStatus() *ApplicationCloneStatusApplyConfiguration {
+	return &ApplicationCloneStatusApplyConfiguration{}
+}
+
+// WithResources adds the given value to the Resources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Resources field.
+func (b *ApplicationCloneStatusApplyConfiguration) WithResources(values ...*ResourceApplyConfiguration) *ApplicationCloneStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResources")
+		}
+		b.Resources = append(b.Resources, *values[i])
+	}
+	return b
+}
+
+// WithError sets the Error field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Error field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithError(value string) *ApplicationCloneStatusApplyConfiguration {
+	b.Error = &value
+	return b
+}
+
+// WithLastSuccessfulAttempt sets the LastSuccessfulAttempt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastSuccessfulAttempt field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithLastSuccessfulAttempt(value string) *ApplicationCloneStatusApplyConfiguration {
+	b.LastSuccessfulAttempt = &value
+	return b
+}
+
+// WithLastAttempt sets the LastAttempt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastAttempt field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithLastAttempt(value string) *ApplicationCloneStatusApplyConfiguration {
+	b.LastAttempt = &value
+	return b
+}
+
+// WithCommit sets the Commit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Commit field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithCommit(value string) *ApplicationCloneStatusApplyConfiguration {
+	b.Commit = &value
+	return b
+}
+
+// WithPendingSecrets adds the given value to the PendingSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PendingSecrets field.
+func (b *ApplicationCloneStatusApplyConfiguration) WithPendingSecrets(values ...string) *ApplicationCloneStatusApplyConfiguration {
+	for i := range values {
+		b.PendingSecrets = append(b.PendingSecrets, values[i])
+	}
+	return b
+}
+
+// WithSanitized adds the given value to the Sanitized field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Sanitized field.
+func (b *ApplicationCloneStatusApplyConfiguration) WithSanitized(values ...*SanitizedFieldApplyConfiguration) *ApplicationCloneStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSanitized")
+		}
+		b.Sanitized = append(b.Sanitized, *values[i])
+	}
+	return b
+}
+
+// WithPolicyViolations adds the given value to the PolicyViolations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PolicyViolations field.
+func (b *ApplicationCloneStatusApplyConfiguration) WithPolicyViolations(values ...*PolicyViolationApplyConfiguration) *ApplicationCloneStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPolicyViolations")
+		}
+		b.PolicyViolations = append(b.PolicyViolations, *values[i])
+	}
+	return b
+}
+
+// WithPendingApproval sets the PendingApproval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PendingApproval field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithPendingApproval(value bool) *ApplicationCloneStatusApplyConfiguration {
+	b.PendingApproval = &value
+	return b
+}
+
+// WithApproval sets the Approval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Approval field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithApproval(value *ApprovalRecordApplyConfiguration) *ApplicationCloneStatusApplyConfiguration {
+	b.Approval = value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+// BuildSettingsApplyConfiguration represents an declarative configuration of the BuildSettings type for use
+// with apply.
+type BuildSettingsApplyConfiguration struct {
+	ImageVisibility *v1alpha1.ImageVisibility `json:"imageVisibility,omitempty"`
+	InitialBuild    *bool                     `json:"initialBuild,omitempty"`
+	Annotations     map[string]string         `json:"annotations,omitempty"`
+}
+
+// BuildSettingsApplyConfiguration constructs an declarative configuration of the BuildSettings type for use with
+// apply.
+func BuildSettings() *BuildSettingsApplyConfiguration {
+	return &BuildSettingsApplyConfiguration{}
+}
+
+// WithImageVisibility sets the ImageVisibility field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImageVisibility field is set to the value of the last call.
+func (b *BuildSettingsApplyConfiguration) WithImageVisibility(value v1alpha1.ImageVisibility) *BuildSettingsApplyConfiguration {
+	b.ImageVisibility = &value
+	return b
+}
+
+// WithInitialBuild sets the InitialBuild field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InitialBuild field is set to the value of the last call.
+func (b *BuildSettingsApplyConfiguration) WithInitialBuild(value bool) *BuildSettingsApplyConfiguration {
+	b.InitialBuild = &value
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *BuildSettingsApplyConfiguration) WithAnnotations(entries map[string]string) *BuildSettingsApplyConfiguration {
+	if b.Annotations == nil && len(entries) > 0 {
+		b.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Annotations[k] = v
+	}
+	return b
+}
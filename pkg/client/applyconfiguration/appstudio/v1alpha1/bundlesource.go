@@ -0,0 +1,47 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// BundleSourceApplyConfiguration represents an declarative configuration of the BundleSource type for use
+// with apply.
+type BundleSourceApplyConfiguration struct {
+	ConfigMap *ConfigMapBundleSourceApplyConfiguration `json:"configMap,omitempty"`
+	OCI       *OCIArtifactApplyConfiguration           `json:"oci,omitempty"`
+}
+
+// BundleSourceApplyConfiguration constructs an declarative configuration of the BundleSource type for use with
+// apply.
+func BundleSource() *BundleSourceApplyConfiguration {
+	return &BundleSourceApplyConfiguration{}
+}
+
+// WithConfigMap sets the ConfigMap field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConfigMap field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithConfigMap(value *ConfigMapBundleSourceApplyConfiguration) *BundleSourceApplyConfiguration {
+	b.ConfigMap = value
+	return b
+}
+
+// WithOCI sets the OCI field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OCI field is set to the value of the last call.
+func (b *BundleSourceApplyConfiguration) WithOCI(value *OCIArtifactApplyConfiguration) *BundleSourceApplyConfiguration {
+	b.OCI = value
+	return b
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+// ComponentSourceApplyConfiguration represents an declarative configuration of the ComponentSource type for use
+// with apply.
+type ComponentSourceApplyConfiguration struct {
+	Name  *string                          `json:"name,omitempty"`
+	Mode  *v1alpha1.ComponentMode          `json:"mode,omitempty"`
+	Build *BuildSettingsApplyConfiguration `json:"build,omitempty"`
+}
+
+// ComponentSourceApplyConfiguration constructs an declarative configuration of the ComponentSource type for use with
+// apply.
+func ComponentSource() *ComponentSourceApplyConfiguration {
+	return &ComponentSourceApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ComponentSourceApplyConfiguration) WithName(value string) *ComponentSourceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *ComponentSourceApplyConfiguration) WithMode(value v1alpha1.ComponentMode) *ComponentSourceApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithBuild sets the Build field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Build field is set to the value of the last call.
+func (b *ComponentSourceApplyConfiguration) WithBuild(value *BuildSettingsApplyConfiguration) *ComponentSourceApplyConfiguration {
+	b.Build = value
+	return b
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ConfigMapOverrideApplyConfiguration represents an declarative configuration of the ConfigMapOverride type for use
+// with apply.
+type ConfigMapOverrideApplyConfiguration struct {
+	Name *string           `json:"name,omitempty"`
+	Data map[string]string `json:"data,omitempty"`
+}
+
+// ConfigMapOverrideApplyConfiguration constructs an declarative configuration of the ConfigMapOverride type for use with
+// apply.
+func ConfigMapOverride() *ConfigMapOverrideApplyConfiguration {
+	return &ConfigMapOverrideApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ConfigMapOverrideApplyConfiguration) WithName(value string) *ConfigMapOverrideApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithData puts the entries into the Data field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Data field,
+// overwriting an existing map entries in Data field with the same key.
+func (b *ConfigMapOverrideApplyConfiguration) WithData(entries map[string]string) *ConfigMapOverrideApplyConfiguration {
+	if b.Data == nil && len(entries) > 0 {
+		b.Data = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Data[k] = v
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// EnvironmentCloningApplyConfiguration represents an declarative configuration of the EnvironmentCloning type for use
+// with apply.
+type EnvironmentCloningApplyConfiguration struct {
+	Clone      *bool                                  `json:"clone,omitempty"`
+	NamePrefix *string                                `json:"namePrefix,omitempty"`
+	NameSuffix *string                                `json:"nameSuffix,omitempty"`
+	Mappings   []EnvironmentMappingApplyConfiguration `json:"mappings,omitempty"`
+}
+
+// EnvironmentCloningApplyConfiguration constructs an declarative configuration of the EnvironmentCloning type for use with
+// apply.
+func EnvironmentCloning() *EnvironmentCloningApplyConfiguration {
+	return &EnvironmentCloningApplyConfiguration{}
+}
+
+// WithClone sets the Clone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Clone field is set to the value of the last call.
+func (b *EnvironmentCloningApplyConfiguration) WithClone(value bool) *EnvironmentCloningApplyConfiguration {
+	b.Clone = &value
+	return b
+}
+
+// WithNamePrefix sets the NamePrefix field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NamePrefix field is set to the value of the last call.
+func (b *EnvironmentCloningApplyConfiguration) WithNamePrefix(value string) *EnvironmentCloningApplyConfiguration {
+	b.NamePrefix = &value
+	return b
+}
+
+// WithNameSuffix sets the NameSuffix field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NameSuffix field is set to the value of the last call.
+func (b *EnvironmentCloningApplyConfiguration) WithNameSuffix(value string) *EnvironmentCloningApplyConfiguration {
+	b.NameSuffix = &value
+	return b
+}
+
+// WithMappings adds the given value to the Mappings field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Mappings field.
+func (b *EnvironmentCloningApplyConfiguration) WithMappings(values ...*EnvironmentMappingApplyConfiguration) *EnvironmentCloningApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMappings")
+		}
+		b.Mappings = append(b.Mappings, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// FromApplyConfiguration represents an declarative configuration of the From type for use
+// with apply.
+type FromApplyConfiguration struct {
+	Namespace  *string                         `json:"namespace,omitempty"`
+	Name       *string                         `json:"name,omitempty"`
+	ClusterRef *ClusterRefApplyConfiguration   `json:"clusterRef,omitempty"`
+	Bundle     *BundleSourceApplyConfiguration `json:"bundle,omitempty"`
+}
+
+// FromApplyConfiguration constructs an declarative configuration of the From type for use with
+// apply.
+func From() *FromApplyConfiguration {
+	return &FromApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *FromApplyConfiguration) WithNamespace(value string) *FromApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *FromApplyConfiguration) WithName(value string) *FromApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithClusterRef sets the ClusterRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterRef field is set to the value of the last call.
+func (b *FromApplyConfiguration) WithClusterRef(value *ClusterRefApplyConfiguration) *FromApplyConfiguration {
+	b.ClusterRef = value
+	return b
+}
+
+// WithBundle sets the Bundle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Bundle field is set to the value of the last call.
+func (b *FromApplyConfiguration) WithBundle(value *BundleSourceApplyConfiguration) *FromApplyConfiguration {
+	b.Bundle = value
+	return b
+}
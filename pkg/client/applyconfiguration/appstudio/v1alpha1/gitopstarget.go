@@ -0,0 +1,65 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// GitOpsTargetApplyConfiguration represents an declarative configuration of the GitOpsTarget type for use
+// with apply.
+type GitOpsTargetApplyConfiguration struct {
+	URL               *string `json:"url,omitempty"`
+	Branch            *string `json:"branch,omitempty"`
+	Path              *string `json:"path,omitempty"`
+	CredentialsSecret *string `json:"credentialsSecret,omitempty"`
+}
+
+// GitOpsTargetApplyConfiguration constructs an declarative configuration of the GitOpsTarget type for use with
+// apply.
+func GitOpsTarget() *GitOpsTargetApplyConfiguration {
+	return &GitOpsTargetApplyConfiguration{}
+}
+
+// WithURL sets the URL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URL field is set to the value of the last call.
+func (b *GitOpsTargetApplyConfiguration) WithURL(value string) *GitOpsTargetApplyConfiguration {
+	b.URL = &value
+	return b
+}
+
+// WithBranch sets the Branch field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Branch field is set to the value of the last call.
+func (b *GitOpsTargetApplyConfiguration) WithBranch(value string) *GitOpsTargetApplyConfiguration {
+	b.Branch = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *GitOpsTargetApplyConfiguration) WithPath(value string) *GitOpsTargetApplyConfiguration {
+	b.Path = &value
+	return b
+}
+
+// WithCredentialsSecret sets the CredentialsSecret field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CredentialsSecret field is set to the value of the last call.
+func (b *GitOpsTargetApplyConfiguration) WithCredentialsSecret(value string) *GitOpsTargetApplyConfiguration {
+	b.CredentialsSecret = &value
+	return b
+}
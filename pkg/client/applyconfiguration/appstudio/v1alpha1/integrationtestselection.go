@@ -0,0 +1,84 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IntegrationTestSelectionApplyConfiguration represents an declarative configuration of the IntegrationTestSelection type for use
+// with apply.
+type IntegrationTestSelectionApplyConfiguration struct {
+	None     *bool             `json:"none,omitempty"`
+	Include  []string          `json:"include,omitempty"`
+	Exclude  []string          `json:"exclude,omitempty"`
+	Selector *v1.LabelSelector `json:"selector,omitempty"`
+	Contexts []string          `json:"contexts,omitempty"`
+}
+
+// IntegrationTestSelectionApplyConfiguration constructs an declarative configuration of the IntegrationTestSelection type for use with
+// apply.
+func IntegrationTestSelection() *IntegrationTestSelectionApplyConfiguration {
+	return &IntegrationTestSelectionApplyConfiguration{}
+}
+
+// WithNone sets the None field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the None field is set to the value of the last call.
+func (b *IntegrationTestSelectionApplyConfiguration) WithNone(value bool) *IntegrationTestSelectionApplyConfiguration {
+	b.None = &value
+	return b
+}
+
+// WithInclude adds the given value to the Include field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Include field.
+func (b *IntegrationTestSelectionApplyConfiguration) WithInclude(values ...string) *IntegrationTestSelectionApplyConfiguration {
+	for i := range values {
+		b.Include = append(b.Include, values[i])
+	}
+	return b
+}
+
+// WithExclude adds the given value to the Exclude field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Exclude field.
+func (b *IntegrationTestSelectionApplyConfiguration) WithExclude(values ...string) *IntegrationTestSelectionApplyConfiguration {
+	for i := range values {
+		b.Exclude = append(b.Exclude, values[i])
+	}
+	return b
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *IntegrationTestSelectionApplyConfiguration) WithSelector(value v1.LabelSelector) *IntegrationTestSelectionApplyConfiguration {
+	b.Selector = &value
+	return b
+}
+
+// WithContexts adds the given value to the Contexts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Contexts field.
+func (b *IntegrationTestSelectionApplyConfiguration) WithContexts(values ...string) *IntegrationTestSelectionApplyConfiguration {
+	for i := range values {
+		b.Contexts = append(b.Contexts, values[i])
+	}
+	return b
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// PolicyViolationApplyConfiguration represents an declarative configuration of the PolicyViolation type for use
+// with apply.
+type PolicyViolationApplyConfiguration struct {
+	Policy  *string `json:"policy,omitempty"`
+	Message *string `json:"message,omitempty"`
+}
+
+// PolicyViolationApplyConfiguration constructs an declarative configuration of the PolicyViolation type for use with
+// apply.
+func PolicyViolation() *PolicyViolationApplyConfiguration {
+	return &PolicyViolationApplyConfiguration{}
+}
+
+// WithPolicy sets the Policy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Policy field is set to the value of the last call.
+func (b *PolicyViolationApplyConfiguration) WithPolicy(value string) *PolicyViolationApplyConfiguration {
+	b.Policy = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *PolicyViolationApplyConfiguration) WithMessage(value string) *PolicyViolationApplyConfiguration {
+	b.Message = &value
+	return b
+}
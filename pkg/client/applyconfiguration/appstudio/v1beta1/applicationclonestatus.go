@@ -0,0 +1,133 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationCloneStatusApplyConfiguration represents an declarative configuration of the ApplicationCloneStatus type for use
+// with apply.
+type ApplicationCloneStatusApplyConfiguration struct {
+	Conditions       []v1.Condition                      `json:"conditions,omitempty"`
+	Resources        []ResourceApplyConfiguration        `json:"resources,omitempty"`
+	LastAttemptTime  *v1.Time                            `json:"lastAttemptTime,omitempty"`
+	LastSuccessTime  *v1.Time                            `json:"lastSuccessTime,omitempty"`
+	Commit           *string                             `json:"commit,omitempty"`
+	PendingSecrets   []string                            `json:"pendingSecrets,omitempty"`
+	Sanitized        []SanitizedFieldApplyConfiguration  `json:"sanitized,omitempty"`
+	PolicyViolations []PolicyViolationApplyConfiguration `json:"policyViolations,omitempty"`
+	Approval         *ApprovalRecordApplyConfiguration   `json:"approval,omitempty"`
+}
+
+// ApplicationCloneStatusApplyConfiguration constructs an declarative configuration of the ApplicationCloneStatus type for use with
+// apply.
+func ApplicationCloneStatus() *ApplicationCloneStatusApplyConfiguration {
+	return &ApplicationCloneStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *ApplicationCloneStatusApplyConfiguration) WithConditions(values ...v1.Condition) *ApplicationCloneStatusApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
+
+// WithResources adds the given value to the Resources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Resources field.
+func (b *ApplicationCloneStatusApplyConfiguration) WithResources(values ...*ResourceApplyConfiguration) *ApplicationCloneStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResources")
+		}
+		b.Resources = append(b.Resources, *values[i])
+	}
+	return b
+}
+
+// WithLastAttemptTime sets the LastAttemptTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastAttemptTime field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithLastAttemptTime(value v1.Time) *ApplicationCloneStatusApplyConfiguration {
+	b.LastAttemptTime = &value
+	return b
+}
+
+// WithLastSuccessTime sets the LastSuccessTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastSuccessTime field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithLastSuccessTime(value v1.Time) *ApplicationCloneStatusApplyConfiguration {
+	b.LastSuccessTime = &value
+	return b
+}
+
+// WithCommit sets the Commit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Commit field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithCommit(value string) *ApplicationCloneStatusApplyConfiguration {
+	b.Commit = &value
+	return b
+}
+
+// WithPendingSecrets adds the given value to the PendingSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PendingSecrets field.
+func (b *ApplicationCloneStatusApplyConfiguration) WithPendingSecrets(values ...string) *ApplicationCloneStatusApplyConfiguration {
+	for i := range values {
+		b.PendingSecrets = append(b.PendingSecrets, values[i])
+	}
+	return b
+}
+
+// WithSanitized adds the given value to the Sanitized field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Sanitized field.
+func (b *ApplicationCloneStatusApplyConfiguration) WithSanitized(values ...*SanitizedFieldApplyConfiguration) *ApplicationCloneStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSanitized")
+		}
+		b.Sanitized = append(b.Sanitized, *values[i])
+	}
+	return b
+}
+
+// WithPolicyViolations adds the given value to the PolicyViolations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PolicyViolations field.
+func (b *ApplicationCloneStatusApplyConfiguration) WithPolicyViolations(values ...*PolicyViolationApplyConfiguration) *ApplicationCloneStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPolicyViolations")
+		}
+		b.PolicyViolations = append(b.PolicyViolations, *values[i])
+	}
+	return b
+}
+
+// WithApproval sets the Approval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Approval field is set to the value of the last call.
+func (b *ApplicationCloneStatusApplyConfiguration) WithApproval(value *ApprovalRecordApplyConfiguration) *ApplicationCloneStatusApplyConfiguration {
+	b.Approval = value
+	return b
+}
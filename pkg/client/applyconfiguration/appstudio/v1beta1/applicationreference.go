@@ -0,0 +1,56 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ApplicationReferenceApplyConfiguration represents an declarative configuration of the ApplicationReference type for use
+// with apply.
+type ApplicationReferenceApplyConfiguration struct {
+	Namespace *string                       `json:"namespace,omitempty"`
+	Name      *string                       `json:"name,omitempty"`
+	Cluster   *ClusterRefApplyConfiguration `json:"cluster,omitempty"`
+}
+
+// ApplicationReferenceApplyConfiguration constructs an declarative configuration of the ApplicationReference type for use with
+// apply.
+func ApplicationReference() *ApplicationReferenceApplyConfiguration {
+	return &ApplicationReferenceApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ApplicationReferenceApplyConfiguration) WithNamespace(value string) *ApplicationReferenceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ApplicationReferenceApplyConfiguration) WithName(value string) *ApplicationReferenceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithCluster sets the Cluster field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cluster field is set to the value of the last call.
+func (b *ApplicationReferenceApplyConfiguration) WithCluster(value *ClusterRefApplyConfiguration) *ApplicationReferenceApplyConfiguration {
+	b.Cluster = value
+	return b
+}
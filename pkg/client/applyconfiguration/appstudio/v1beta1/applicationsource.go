@@ -0,0 +1,47 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ApplicationSourceApplyConfiguration represents an declarative configuration of the ApplicationSource type for use
+// with apply.
+type ApplicationSourceApplyConfiguration struct {
+	Application *ApplicationReferenceApplyConfiguration `json:"application,omitempty"`
+	Bundle      *BundleReferenceApplyConfiguration      `json:"bundle,omitempty"`
+}
+
+// ApplicationSourceApplyConfiguration constructs an declarative configuration of the ApplicationSource type for use with
+// apply.
+func ApplicationSource() *ApplicationSourceApplyConfiguration {
+	return &ApplicationSourceApplyConfiguration{}
+}
+
+// WithApplication sets the Application field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Application field is set to the value of the last call.
+func (b *ApplicationSourceApplyConfiguration) WithApplication(value *ApplicationReferenceApplyConfiguration) *ApplicationSourceApplyConfiguration {
+	b.Application = value
+	return b
+}
+
+// WithBundle sets the Bundle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Bundle field is set to the value of the last call.
+func (b *ApplicationSourceApplyConfiguration) WithBundle(value *BundleReferenceApplyConfiguration) *ApplicationSourceApplyConfiguration {
+	b.Bundle = value
+	return b
+}
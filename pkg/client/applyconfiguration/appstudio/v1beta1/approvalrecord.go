@@ -0,0 +1,60 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApprovalRecordApplyConfiguration represents an declarative configuration of the ApprovalRecord type for use
+// with apply.
+type ApprovalRecordApplyConfiguration struct {
+	Name           *string  `json:"name,omitempty"`
+	Approver       *string  `json:"approver,omitempty"`
+	ExpirationTime *v1.Time `json:"expirationTime,omitempty"`
+}
+
+// ApprovalRecordApplyConfiguration constructs an declarative configuration of the ApprovalRecord type for use with
+// apply.
+func ApprovalRecord() *ApprovalRecordApplyConfiguration {
+	return &ApprovalRecordApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ApprovalRecordApplyConfiguration) WithName(value string) *ApprovalRecordApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithApprover sets the Approver field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Approver field is set to the value of the last call.
+func (b *ApprovalRecordApplyConfiguration) WithApprover(value string) *ApprovalRecordApplyConfiguration {
+	b.Approver = &value
+	return b
+}
+
+// WithExpirationTime sets the ExpirationTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpirationTime field is set to the value of the last call.
+func (b *ApprovalRecordApplyConfiguration) WithExpirationTime(value v1.Time) *ApprovalRecordApplyConfiguration {
+	b.ExpirationTime = &value
+	return b
+}
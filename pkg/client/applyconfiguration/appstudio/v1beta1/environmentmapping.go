@@ -0,0 +1,47 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// EnvironmentMappingApplyConfiguration represents an declarative configuration of the EnvironmentMapping type for use
+// with apply.
+type EnvironmentMappingApplyConfiguration struct {
+	From *string `json:"from,omitempty"`
+	To   *string `json:"to,omitempty"`
+}
+
+// EnvironmentMappingApplyConfiguration constructs an declarative configuration of the EnvironmentMapping type for use with
+// apply.
+func EnvironmentMapping() *EnvironmentMappingApplyConfiguration {
+	return &EnvironmentMappingApplyConfiguration{}
+}
+
+// WithFrom sets the From field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the From field is set to the value of the last call.
+func (b *EnvironmentMappingApplyConfiguration) WithFrom(value string) *EnvironmentMappingApplyConfiguration {
+	b.From = &value
+	return b
+}
+
+// WithTo sets the To field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the To field is set to the value of the last call.
+func (b *EnvironmentMappingApplyConfiguration) WithTo(value string) *EnvironmentMappingApplyConfiguration {
+	b.To = &value
+	return b
+}
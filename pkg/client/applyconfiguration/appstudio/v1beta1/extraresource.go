@@ -0,0 +1,60 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExtraResourceApplyConfiguration represents an declarative configuration of the ExtraResource type for use
+// with apply.
+type ExtraResourceApplyConfiguration struct {
+	APIVersion *string           `json:"apiVersion,omitempty"`
+	Kind       *string           `json:"kind,omitempty"`
+	Selector   *v1.LabelSelector `json:"selector,omitempty"`
+}
+
+// ExtraResourceApplyConfiguration constructs an declarative configuration of the ExtraResource type for use with
+// apply.
+func ExtraResource() *ExtraResourceApplyConfiguration {
+	return &ExtraResourceApplyConfiguration{}
+}
+
+// WithAPIVersion sets the APIVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the APIVersion field is set to the value of the last call.
+func (b *ExtraResourceApplyConfiguration) WithAPIVersion(value string) *ExtraResourceApplyConfiguration {
+	b.APIVersion = &value
+	return b
+}
+
+// WithKind sets the Kind field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kind field is set to the value of the last call.
+func (b *ExtraResourceApplyConfiguration) WithKind(value string) *ExtraResourceApplyConfiguration {
+	b.Kind = &value
+	return b
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *ExtraResourceApplyConfiguration) WithSelector(value v1.LabelSelector) *ExtraResourceApplyConfiguration {
+	b.Selector = &value
+	return b
+}
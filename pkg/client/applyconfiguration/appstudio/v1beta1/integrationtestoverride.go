@@ -0,0 +1,75 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// IntegrationTestOverrideApplyConfiguration represents an declarative configuration of the IntegrationTestOverride type for use
+// with apply.
+type IntegrationTestOverrideApplyConfiguration struct {
+	Name           *string                           `json:"name,omitempty"`
+	ResolverParams []ResolverParamApplyConfiguration `json:"resolverParams,omitempty"`
+	Params         []PipelineParamApplyConfiguration `json:"params,omitempty"`
+	Environment    *string                           `json:"environment,omitempty"`
+}
+
+// IntegrationTestOverrideApplyConfiguration constructs an declarative configuration of the IntegrationTestOverride type for use with
+// apply.
+func IntegrationTestOverride() *IntegrationTestOverrideApplyConfiguration {
+	return &IntegrationTestOverrideApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *IntegrationTestOverrideApplyConfiguration) WithName(value string) *IntegrationTestOverrideApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithResolverParams adds the given value to the ResolverParams field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResolverParams field.
+func (b *IntegrationTestOverrideApplyConfiguration) WithResolverParams(values ...*ResolverParamApplyConfiguration) *IntegrationTestOverrideApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResolverParams")
+		}
+		b.ResolverParams = append(b.ResolverParams, *values[i])
+	}
+	return b
+}
+
+// WithParams adds the given value to the Params field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Params field.
+func (b *IntegrationTestOverrideApplyConfiguration) WithParams(values ...*PipelineParamApplyConfiguration) *IntegrationTestOverrideApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithParams")
+		}
+		b.Params = append(b.Params, *values[i])
+	}
+	return b
+}
+
+// WithEnvironment sets the Environment field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Environment field is set to the value of the last call.
+func (b *IntegrationTestOverrideApplyConfiguration) WithEnvironment(value string) *IntegrationTestOverrideApplyConfiguration {
+	b.Environment = &value
+	return b
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+)
+
+// SanitizationRuleApplyConfiguration represents an declarative configuration of the SanitizationRule type for use
+// with apply.
+type SanitizationRuleApplyConfiguration struct {
+	Name        *string                     `json:"name,omitempty"`
+	Value       *string                     `json:"value,omitempty"`
+	Action      *v1beta1.SanitizationAction `json:"action,omitempty"`
+	Replacement *string                     `json:"replacement,omitempty"`
+}
+
+// SanitizationRuleApplyConfiguration constructs an declarative configuration of the SanitizationRule type for use with
+// apply.
+func SanitizationRule() *SanitizationRuleApplyConfiguration {
+	return &SanitizationRuleApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *SanitizationRuleApplyConfiguration) WithName(value string) *SanitizationRuleApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithValue sets the Value field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Value field is set to the value of the last call.
+func (b *SanitizationRuleApplyConfiguration) WithValue(value string) *SanitizationRuleApplyConfiguration {
+	b.Value = &value
+	return b
+}
+
+// WithAction sets the Action field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Action field is set to the value of the last call.
+func (b *SanitizationRuleApplyConfiguration) WithAction(value v1beta1.SanitizationAction) *SanitizationRuleApplyConfiguration {
+	b.Action = &value
+	return b
+}
+
+// WithReplacement sets the Replacement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replacement field is set to the value of the last call.
+func (b *SanitizationRuleApplyConfiguration) WithReplacement(value string) *SanitizationRuleApplyConfiguration {
+	b.Replacement = &value
+	return b
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+)
+
+// SanitizedFieldApplyConfiguration represents an declarative configuration of the SanitizedField type for use
+// with apply.
+type SanitizedFieldApplyConfiguration struct {
+	Component *string                     `json:"component,omitempty"`
+	Env       *string                     `json:"env,omitempty"`
+	Action    *v1beta1.SanitizationAction `json:"action,omitempty"`
+}
+
+// SanitizedFieldApplyConfiguration constructs an declarative configuration of the SanitizedField type for use with
+// apply.
+func SanitizedField() *SanitizedFieldApplyConfiguration {
+	return &SanitizedFieldApplyConfiguration{}
+}
+
+// WithComponent sets the Component field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Component field is set to the value of the last call.
+func (b *SanitizedFieldApplyConfiguration) WithComponent(value string) *SanitizedFieldApplyConfiguration {
+	b.Component = &value
+	return b
+}
+
+// WithEnv sets the Env field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Env field is set to the value of the last call.
+func (b *SanitizedFieldApplyConfiguration) WithEnv(value string) *SanitizedFieldApplyConfiguration {
+	b.Env = &value
+	return b
+}
+
+// WithAction sets the Action field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Action field is set to the value of the last call.
+func (b *SanitizedFieldApplyConfiguration) WithAction(value v1beta1.SanitizationAction) *SanitizedFieldApplyConfiguration {
+	b.Action = &value
+	return b
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+)
+
+// SecretCloningApplyConfiguration represents an declarative configuration of the SecretCloning type for use
+// with apply.
+type SecretCloningApplyConfiguration struct {
+	Name     *string                 `json:"name,omitempty"`
+	Strategy *v1beta1.SecretStrategy `json:"strategy,omitempty"`
+}
+
+// SecretCloningApplyConfiguration constructs an declarative configuration of the SecretCloning type for use with
+// apply.
+func SecretCloning() *SecretCloningApplyConfiguration {
+	return &SecretCloningApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *SecretCloningApplyConfiguration) WithName(value string) *SecretCloningApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithStrategy sets the Strategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Strategy field is set to the value of the last call.
+func (b *SecretCloningApplyConfiguration) WithStrategy(value v1beta1.SecretStrategy) *SecretCloningApplyConfiguration {
+	b.Strategy = &value
+	return b
+}
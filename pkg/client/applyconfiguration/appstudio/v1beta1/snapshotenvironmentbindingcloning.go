@@ -0,0 +1,49 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// SnapshotEnvironmentBindingCloningApplyConfiguration represents an declarative configuration of the SnapshotEnvironmentBindingCloning type for use
+// with apply.
+type SnapshotEnvironmentBindingCloningApplyConfiguration struct {
+	Clone      *bool    `json:"clone,omitempty"`
+	RewriteEnv []string `json:"rewriteEnv,omitempty"`
+}
+
+// SnapshotEnvironmentBindingCloningApplyConfiguration constructs an declarative configuration of the SnapshotEnvironmentBindingCloning type for use with
+// apply.
+func SnapshotEnvironmentBindingCloning() *SnapshotEnvironmentBindingCloningApplyConfiguration {
+	return &SnapshotEnvironmentBindingCloningApplyConfiguration{}
+}
+
+// WithClone sets the Clone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Clone field is set to the value of the last call.
+func (b *SnapshotEnvironmentBindingCloningApplyConfiguration) WithClone(value bool) *SnapshotEnvironmentBindingCloningApplyConfiguration {
+	b.Clone = &value
+	return b
+}
+
+// WithRewriteEnv adds the given value to the RewriteEnv field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RewriteEnv field.
+func (b *SnapshotEnvironmentBindingCloningApplyConfiguration) WithRewriteEnv(values ...string) *SnapshotEnvironmentBindingCloningApplyConfiguration {
+	for i := range values {
+		b.RewriteEnv = append(b.RewriteEnv, values[i])
+	}
+	return b
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	v1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+	appstudiov1alpha1 "github.com/redhat-appstudio/clone-controller/pkg/client/applyconfiguration/appstudio/v1alpha1"
+	appstudiov1beta1 "github.com/redhat-appstudio/clone-controller/pkg/client/applyconfiguration/appstudio/v1beta1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=appstudio, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("ApplicationClone"):
+		return &appstudiov1alpha1.ApplicationCloneApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ApplicationCloneSpec"):
+		return &appstudiov1alpha1.ApplicationCloneSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ApplicationCloneStatus"):
+		return &appstudiov1alpha1.ApplicationCloneStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ApprovalRecord"):
+		return &appstudiov1alpha1.ApprovalRecordApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BuildSettings"):
+		return &appstudiov1alpha1.BuildSettingsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BundleSource"):
+		return &appstudiov1alpha1.BundleSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterRef"):
+		return &appstudiov1alpha1.ClusterRefApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ComponentSource"):
+		return &appstudiov1alpha1.ComponentSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ConfigMapBundleSource"):
+		return &appstudiov1alpha1.ConfigMapBundleSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ConfigMapOverride"):
+		return &appstudiov1alpha1.ConfigMapOverrideApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("EnvironmentCloning"):
+		return &appstudiov1alpha1.EnvironmentCloningApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("EnvironmentMapping"):
+		return &appstudiov1alpha1.EnvironmentMappingApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ExtraResource"):
+		return &appstudiov1alpha1.ExtraResourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("From"):
+		return &appstudiov1alpha1.FromApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("GitOpsTarget"):
+		return &appstudiov1alpha1.GitOpsTargetApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("IntegrationTestOverride"):
+		return &appstudiov1alpha1.IntegrationTestOverrideApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("IntegrationTestSelection"):
+		return &appstudiov1alpha1.IntegrationTestSelectionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OCIArtifact"):
+		return &appstudiov1alpha1.OCIArtifactApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PipelineParam"):
+		return &appstudiov1alpha1.PipelineParamApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PolicyViolation"):
+		return &appstudiov1alpha1.PolicyViolationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResolverParam"):
+		return &appstudiov1alpha1.ResolverParamApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Resource"):
+		return &appstudiov1alpha1.ResourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SanitizationRule"):
+		return &appstudiov1alpha1.SanitizationRuleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SanitizedField"):
+		return &appstudiov1alpha1.SanitizedFieldApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SecretCloning"):
+		return &appstudiov1alpha1.SecretCloningApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SnapshotEnvironmentBindingCloning"):
+		return &appstudiov1alpha1.SnapshotEnvironmentBindingCloningApplyConfiguration{}
+
+		// Group=appstudio, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithKind("ApplicationClone"):
+		return &appstudiov1beta1.ApplicationCloneApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ApplicationCloneSpec"):
+		return &appstudiov1beta1.ApplicationCloneSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ApplicationCloneStatus"):
+		return &appstudiov1beta1.ApplicationCloneStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ApplicationReference"):
+		return &appstudiov1beta1.ApplicationReferenceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ApplicationSource"):
+		return &appstudiov1beta1.ApplicationSourceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ApprovalRecord"):
+		return &appstudiov1beta1.ApprovalRecordApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("BuildSettings"):
+		return &appstudiov1beta1.BuildSettingsApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("BundleReference"):
+		return &appstudiov1beta1.BundleReferenceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterRef"):
+		return &appstudiov1beta1.ClusterRefApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ComponentCloning"):
+		return &appstudiov1beta1.ComponentCloningApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ConfigMapBundleSource"):
+		return &appstudiov1beta1.ConfigMapBundleSourceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ConfigMapOverride"):
+		return &appstudiov1beta1.ConfigMapOverrideApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("EnvironmentCloning"):
+		return &appstudiov1beta1.EnvironmentCloningApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("EnvironmentMapping"):
+		return &appstudiov1beta1.EnvironmentMappingApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ExtraResource"):
+		return &appstudiov1beta1.ExtraResourceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("GitOpsTarget"):
+		return &appstudiov1beta1.GitOpsTargetApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("IntegrationTestOverride"):
+		return &appstudiov1beta1.IntegrationTestOverrideApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("IntegrationTestSelection"):
+		return &appstudiov1beta1.IntegrationTestSelectionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("OCIArtifact"):
+		return &appstudiov1beta1.OCIArtifactApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PipelineParam"):
+		return &appstudiov1beta1.PipelineParamApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PolicyViolation"):
+		return &appstudiov1beta1.PolicyViolationApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResolverParam"):
+		return &appstudiov1beta1.ResolverParamApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("Resource"):
+		return &appstudiov1beta1.ResourceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SanitizationRule"):
+		return &appstudiov1beta1.SanitizationRuleApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SanitizedField"):
+		return &appstudiov1beta1.SanitizedFieldApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SecretCloning"):
+		return &appstudiov1beta1.SecretCloningApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SnapshotEnvironmentBindingCloning"):
+		return &appstudiov1beta1.SnapshotEnvironmentBindingCloningApplyConfiguration{}
+
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+	appstudiov1beta1 "github.com/redhat-appstudio/clone-controller/pkg/client/applyconfiguration/appstudio/v1beta1"
+	scheme "github.com/redhat-appstudio/clone-controller/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ApplicationClonesGetter has a method to return a ApplicationCloneInterface.
+// A group's client should implement this interface.
+type ApplicationClonesGetter interface {
+	ApplicationClones(namespace string) ApplicationCloneInterface
+}
+
+// ApplicationCloneInterface has methods to work with ApplicationClone resources.
+type ApplicationCloneInterface interface {
+	Create(ctx context.Context, applicationClone *v1beta1.ApplicationClone, opts v1.CreateOptions) (*v1beta1.ApplicationClone, error)
+	Update(ctx context.Context, applicationClone *v1beta1.ApplicationClone, opts v1.UpdateOptions) (*v1beta1.ApplicationClone, error)
+	UpdateStatus(ctx context.Context, applicationClone *v1beta1.ApplicationClone, opts v1.UpdateOptions) (*v1beta1.ApplicationClone, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.ApplicationClone, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.ApplicationCloneList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ApplicationClone, err error)
+	Apply(ctx context.Context, applicationClone *appstudiov1beta1.ApplicationCloneApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ApplicationClone, err error)
+	ApplyStatus(ctx context.Context, applicationClone *appstudiov1beta1.ApplicationCloneApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ApplicationClone, err error)
+	ApplicationCloneExpansion
+}
+
+// applicationClones implements ApplicationCloneInterface
+type applicationClones struct {
+	client rest.Interface
+	ns     string
+}
+
+// newApplicationClones returns a ApplicationClones
+func newApplicationClones(c *AppstudioV1beta1Client, namespace string) *applicationClones {
+	return &applicationClones{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the applicationClone, and returns the corresponding applicationClone object, and an error if there is any.
+func (c *applicationClones) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.ApplicationClone, err error) {
+	result = &v1beta1.ApplicationClone{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("applicationclones").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ApplicationClones that match those selectors.
+func (c *applicationClones) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.ApplicationCloneList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.ApplicationCloneList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("applicationclones").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested applicationClones.
+func (c *applicationClones) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("applicationclones").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a applicationClone and creates it.  Returns the server's representation of the applicationClone, and an error, if there is any.
+func (c *applicationClones) Create(ctx context.Context, applicationClone *v1beta1.ApplicationClone, opts v1.CreateOptions) (result *v1beta1.ApplicationClone, err error) {
+	result = &v1beta1.ApplicationClone{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("applicationclones").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(applicationClone).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a applicationClone and updates it. Returns the server's representation of the applicationClone, and an error, if there is any.
+func (c *applicationClones) Update(ctx context.Context, applicationClone *v1beta1.ApplicationClone, opts v1.UpdateOptions) (result *v1beta1.ApplicationClone, err error) {
+	result = &v1beta1.ApplicationClone{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("applicationclones").
+		Name(applicationClone.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(applicationClone).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *applicationClones) UpdateStatus(ctx context.Context, applicationClone *v1beta1.ApplicationClone, opts v1.UpdateOptions) (result *v1beta1.ApplicationClone, err error) {
+	result = &v1beta1.ApplicationClone{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("applicationclones").
+		Name(applicationClone.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(applicationClone).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the applicationClone and deletes it. Returns an error if one occurs.
+func (c *applicationClones) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("applicationclones").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *applicationClones) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("applicationclones").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched applicationClone.
+func (c *applicationClones) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ApplicationClone, err error) {
+	result = &v1beta1.ApplicationClone{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("applicationclones").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied applicationClone.
+func (c *applicationClones) Apply(ctx context.Context, applicationClone *appstudiov1beta1.ApplicationCloneApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ApplicationClone, err error) {
+	if applicationClone == nil {
+		return nil, fmt.Errorf("applicationClone provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(applicationClone)
+	if err != nil {
+		return nil, err
+	}
+	name := applicationClone.Name
+	if name == nil {
+		return nil, fmt.Errorf("applicationClone.Name must be provided to Apply")
+	}
+	result = &v1beta1.ApplicationClone{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("applicationclones").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *applicationClones) ApplyStatus(ctx context.Context, applicationClone *appstudiov1beta1.ApplicationCloneApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ApplicationClone, err error) {
+	if applicationClone == nil {
+		return nil, fmt.Errorf("applicationClone provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(applicationClone)
+	if err != nil {
+		return nil, err
+	}
+
+	name := applicationClone.Name
+	if name == nil {
+		return nil, fmt.Errorf("applicationClone.Name must be provided to Apply")
+	}
+
+	result = &v1beta1.ApplicationClone{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("applicationclones").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
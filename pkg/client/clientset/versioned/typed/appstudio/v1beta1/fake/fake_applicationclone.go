@@ -0,0 +1,188 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1beta1 "github.com/redhat-appstudio/clone-controller/api/v1beta1"
+	appstudiov1beta1 "github.com/redhat-appstudio/clone-controller/pkg/client/applyconfiguration/appstudio/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeApplicationClones implements ApplicationCloneInterface
+type FakeApplicationClones struct {
+	Fake *FakeAppstudioV1beta1
+	ns   string
+}
+
+var applicationclonesResource = v1beta1.SchemeGroupVersion.WithResource("applicationclones")
+
+var applicationclonesKind = v1beta1.SchemeGroupVersion.WithKind("ApplicationClone")
+
+// Get takes name of the applicationClone, and returns the corresponding applicationClone object, and an error if there is any.
+func (c *FakeApplicationClones) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.ApplicationClone, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(applicationclonesResource, c.ns, name), &v1beta1.ApplicationClone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ApplicationClone), err
+}
+
+// List takes label and field selectors, and returns the list of ApplicationClones that match those selectors.
+func (c *FakeApplicationClones) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.ApplicationCloneList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(applicationclonesResource, applicationclonesKind, c.ns, opts), &v1beta1.ApplicationCloneList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.ApplicationCloneList{ListMeta: obj.(*v1beta1.ApplicationCloneList).ListMeta}
+	for _, item := range obj.(*v1beta1.ApplicationCloneList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested applicationClones.
+func (c *FakeApplicationClones) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(applicationclonesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a applicationClone and creates it.  Returns the server's representation of the applicationClone, and an error, if there is any.
+func (c *FakeApplicationClones) Create(ctx context.Context, applicationClone *v1beta1.ApplicationClone, opts v1.CreateOptions) (result *v1beta1.ApplicationClone, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(applicationclonesResource, c.ns, applicationClone), &v1beta1.ApplicationClone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ApplicationClone), err
+}
+
+// Update takes the representation of a applicationClone and updates it. Returns the server's representation of the applicationClone, and an error, if there is any.
+func (c *FakeApplicationClones) Update(ctx context.Context, applicationClone *v1beta1.ApplicationClone, opts v1.UpdateOptions) (result *v1beta1.ApplicationClone, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(applicationclonesResource, c.ns, applicationClone), &v1beta1.ApplicationClone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ApplicationClone), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeApplicationClones) UpdateStatus(ctx context.Context, applicationClone *v1beta1.ApplicationClone, opts v1.UpdateOptions) (*v1beta1.ApplicationClone, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(applicationclonesResource, "status", c.ns, applicationClone), &v1beta1.ApplicationClone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ApplicationClone), err
+}
+
+// Delete takes name of the applicationClone and deletes it. Returns an error if one occurs.
+func (c *FakeApplicationClones) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(applicationclonesResource, c.ns, name, opts), &v1beta1.ApplicationClone{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeApplicationClones) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(applicationclonesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.ApplicationCloneList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched applicationClone.
+func (c *FakeApplicationClones) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ApplicationClone, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(applicationclonesResource, c.ns, name, pt, data, subresources...), &v1beta1.ApplicationClone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ApplicationClone), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied applicationClone.
+func (c *FakeApplicationClones) Apply(ctx context.Context, applicationClone *appstudiov1beta1.ApplicationCloneApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ApplicationClone, err error) {
+	if applicationClone == nil {
+		return nil, fmt.Errorf("applicationClone provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applicationClone)
+	if err != nil {
+		return nil, err
+	}
+	name := applicationClone.Name
+	if name == nil {
+		return nil, fmt.Errorf("applicationClone.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(applicationclonesResource, c.ns, *name, types.ApplyPatchType, data), &v1beta1.ApplicationClone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ApplicationClone), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeApplicationClones) ApplyStatus(ctx context.Context, applicationClone *appstudiov1beta1.ApplicationCloneApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ApplicationClone, err error) {
+	if applicationClone == nil {
+		return nil, fmt.Errorf("applicationClone provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(applicationClone)
+	if err != nil {
+		return nil, err
+	}
+	name := applicationClone.Name
+	if name == nil {
+		return nil, fmt.Errorf("applicationClone.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(applicationclonesResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1beta1.ApplicationClone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ApplicationClone), err
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	appstudiov1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	versioned "github.com/redhat-appstudio/clone-controller/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/redhat-appstudio/clone-controller/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/redhat-appstudio/clone-controller/pkg/client/listers/appstudio/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ApplicationCloneInformer provides access to a shared informer and lister for
+// ApplicationClones.
+type ApplicationCloneInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ApplicationCloneLister
+}
+
+type applicationCloneInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewApplicationCloneInformer constructs a new informer for ApplicationClone type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewApplicationCloneInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredApplicationCloneInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredApplicationCloneInformer constructs a new informer for ApplicationClone type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredApplicationCloneInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AppstudioV1alpha1().ApplicationClones(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AppstudioV1alpha1().ApplicationClones(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&appstudiov1alpha1.ApplicationClone{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *applicationCloneInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredApplicationCloneInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *applicationCloneInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&appstudiov1alpha1.ApplicationClone{}, f.defaultInformer)
+}
+
+func (f *applicationCloneInformer) Lister() v1alpha1.ApplicationCloneLister {
+	return v1alpha1.NewApplicationCloneLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Applier creates the objects of a Plan
+type Applier struct {
+	Client client.Client
+}
+
+// Apply creates the objects of the plan, in order, through the Cloners that planned them. Objects that already
+// exist are left as they are, unless their Cloner applies them otherwise.
+func (a *Applier) Apply(ctx context.Context, plan *Plan) error {
+	for i, obj := range plan.Objects {
+		err := plan.applier(i).Apply(ctx, a.Client, obj)
+		if err != nil {
+			return fmt.Errorf("error creating %s %s: %w", strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind), obj.GetName(), err)
+		}
+	}
+
+	return nil
+}
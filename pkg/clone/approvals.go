@@ -0,0 +1,125 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+)
+
+// ProtectedAnnotation set to "true" on an Application makes its clones and exports, and the clones of its bundles,
+// wait for an ApplicationCloneApproval of its namespace
+const ProtectedAnnotation = "clone.appstudio.redhat.com/protected"
+
+// ErrPendingApproval is returned when a protected Application is cloned without an approval
+var ErrPendingApproval = errors.New("pending approval")
+
+// CheckApproval returns the ApplicationCloneApproval of the namespace of the Application of from approving the
+// requester, an ApplicationClone or an ApplicationExport, to clone or export it, nil if the Application isn't
+// protected. It returns ErrPendingApproval when the Application is protected and no approval that hasn't expired by
+// now and whose approver was recorded approves the current generation of the requester.
+// Protected Applications of remote clusters are refused: the webhook recording the approvers and checking their
+// access may not run there, so their approvals can't be trusted.
+func CheckApproval(ctx context.Context, source client.Reader, requester appstudioredhatcomv1alpha1.CloneReference, from appstudioredhatcomv1alpha1.From, now time.Time) (*appstudioredhatcomv1alpha1.ApprovalRecord, error) {
+	protected, err := isProtected(ctx, source, from)
+	if err != nil || !protected {
+		return nil, err
+	}
+	if from.ClusterRef != nil {
+		return nil, fmt.Errorf("application %s of namespace %s is protected, and protected applications of remote clusters can't be approved", from.Name, from.Namespace)
+	}
+	return findApproval(ctx, source, requester, from, now)
+}
+
+// CheckBundleApproval is CheckApproval for the Application of a bundle, which the reader serves in the namespace
+// of from. The approvals of a protected Application are read through approvals, from the namespace the bundle was
+// exported from, so that cloning a bundle needs the same approval as cloning the Application it was exported from.
+func CheckBundleApproval(ctx context.Context, reader *bundle.Reader, approvals client.Reader, requester appstudioredhatcomv1alpha1.CloneReference, from appstudioredhatcomv1alpha1.From, now time.Time) (*appstudioredhatcomv1alpha1.ApprovalRecord, error) {
+	protected, err := isProtected(ctx, reader, from)
+	if err != nil || !protected {
+		return nil, err
+	}
+	if reader.SourceNamespace() == "" {
+		return nil, fmt.Errorf("application %s of the bundle is protected, but the bundle doesn't record the namespace it was exported from", from.Name)
+	}
+	return findApproval(ctx, approvals, requester, appstudioredhatcomv1alpha1.From{Namespace: reader.SourceNamespace(), Name: from.Name}, now)
+}
+
+// isProtected tells whether the Application of from is annotated as protected. Missing Applications aren't.
+func isProtected(ctx context.Context, source client.Reader, from appstudioredhatcomv1alpha1.From) (bool, error) {
+	application := &hasApplicationAPI.Application{}
+	err := source.Get(ctx, types.NamespacedName{Namespace: from.Namespace, Name: from.Name}, application)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading application %s: %w", from.Name, err)
+	}
+	return application.Annotations[ProtectedAnnotation] == "true", nil
+}
+
+// findApproval returns the ApplicationCloneApproval of the namespace of from approving the requester, see
+// CheckApproval
+func findApproval(ctx context.Context, approvals client.Reader, requester appstudioredhatcomv1alpha1.CloneReference, from appstudioredhatcomv1alpha1.From, now time.Time) (*appstudioredhatcomv1alpha1.ApprovalRecord, error) {
+	approvalList := &appstudioredhatcomv1alpha1.ApplicationCloneApprovalList{}
+	err := approvals.List(ctx, approvalList, &client.ListOptions{Namespace: from.Namespace})
+	if err != nil {
+		return nil, fmt.Errorf("error listing applicationcloneapprovals: %w", err)
+	}
+
+	for _, approval := range approvalList.Items {
+		spec := approval.Spec
+		if spec.Application != from.Name || !sameRequester(spec.Clone, requester) {
+			continue
+		}
+		// an approval is given to a spec, which changes along with the generation, of one resource
+		if spec.Clone.Generation == 0 || spec.Clone.Generation != requester.Generation {
+			continue
+		}
+		if spec.Clone.UID == "" || spec.Clone.UID != requester.UID {
+			continue
+		}
+		if spec.Approver == "" || !now.Before(spec.ExpirationTime.Time) {
+			continue
+		}
+		return &appstudioredhatcomv1alpha1.ApprovalRecord{Name: approval.Name, Approver: spec.Approver, ExpirationTime: spec.ExpirationTime}, nil
+	}
+	return nil, fmt.Errorf("%w: application %s of namespace %s is protected and no ApplicationCloneApproval approves generation %d of %s %s/%s", ErrPendingApproval, from.Name, from.Namespace, requester.Generation, kindOf(requester), requester.Namespace, requester.Name)
+}
+
+// sameRequester tells whether the references name the same ApplicationClone or ApplicationExport
+func sameRequester(a, b appstudioredhatcomv1alpha1.CloneReference) bool {
+	return kindOf(a) == kindOf(b) && a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// kindOf returns the kind of the referenced resource, ApplicationClone unless set
+func kindOf(reference appstudioredhatcomv1alpha1.CloneReference) string {
+	if reference.Kind == "" {
+		return "ApplicationClone"
+	}
+	return reference.Kind
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+)
+
+var _ = Describe("Approvals", func() {
+
+	var source client.Client
+	now := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+	from := appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"}
+	applicationClone := appstudioredhatcomv1alpha1.CloneReference{Kind: "ApplicationClone", Namespace: "bar", Name: "appfoo-clone", Generation: 2, UID: "uid-2"}
+
+	BeforeEach(func() {
+		testScheme := runtime.NewScheme()
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		source = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "appfoo", Namespace: "foo", Annotations: map[string]string{ProtectedAnnotation: "true"}}},
+			&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "appbar", Namespace: "foo"}},
+		).Build()
+	})
+
+	approve := func(name string, spec appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec) {
+		Expect(source.Create(context.Background(), &appstudioredhatcomv1alpha1.ApplicationCloneApproval{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo"},
+			Spec:       spec,
+		})).To(Succeed())
+	}
+
+	It("Should not wait for the approval of unprotected Applications", func() {
+		approval, err := CheckApproval(context.Background(), source, applicationClone, appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appbar"}, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approval).To(BeNil())
+	})
+
+	It("Should wait for a current approval of protected Applications", func() {
+		_, err := CheckApproval(context.Background(), source, applicationClone, from, now)
+		Expect(err).To(MatchError(ErrPendingApproval))
+
+		clone := appstudioredhatcomv1alpha1.CloneReference{Namespace: "bar", Name: "appfoo-clone", Generation: 2, UID: "uid-2"}
+		approve("expired", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: clone, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(-time.Hour)),
+		})
+		approve("other-clone", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: appstudioredhatcomv1alpha1.CloneReference{Namespace: "baz", Name: "appfoo-clone", Generation: 2, UID: "uid-2"}, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		// the spec of the clone changed since it was approved
+		approve("other-generation", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: appstudioredhatcomv1alpha1.CloneReference{Namespace: "bar", Name: "appfoo-clone", Generation: 1, UID: "uid-2"}, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		approve("unknown-generation", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: appstudioredhatcomv1alpha1.CloneReference{Namespace: "bar", Name: "appfoo-clone", UID: "uid-2"}, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		// the clone was deleted and recreated since it was approved
+		approve("other-uid", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: appstudioredhatcomv1alpha1.CloneReference{Namespace: "bar", Name: "appfoo-clone", Generation: 2, UID: "uid-1"}, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		approve("unknown-uid", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: appstudioredhatcomv1alpha1.CloneReference{Namespace: "bar", Name: "appfoo-clone", Generation: 2}, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		// the approver is recorded by the webhook
+		approve("unrecorded", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: clone, ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		_, err = CheckApproval(context.Background(), source, applicationClone, from, now)
+		Expect(err).To(MatchError(ErrPendingApproval))
+		Expect(err).To(MatchError(ContainSubstring("no ApplicationCloneApproval approves generation 2 of ApplicationClone bar/appfoo-clone")))
+
+		approve("approved", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: clone, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		approval, err := CheckApproval(context.Background(), source, applicationClone, from, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approval.Name).To(Equal("approved"))
+		Expect(approval.Approver).To(Equal("alice"))
+	})
+
+	It("Should refuse protected Applications of remote clusters", func() {
+		approve("approved", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: applicationClone, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		remote := from
+		remote.ClusterRef = &appstudioredhatcomv1alpha1.ClusterRef{SecretName: "remote"}
+		_, err := CheckApproval(context.Background(), source, applicationClone, remote, now)
+		Expect(err).To(MatchError("application appfoo of namespace foo is protected, and protected applications of remote clusters can't be approved"))
+
+		approval, err := CheckApproval(context.Background(), source, applicationClone, appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appbar", ClusterRef: remote.ClusterRef}, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approval).To(BeNil())
+	})
+
+	It("Should tell approvals of clones from approvals of exports", func() {
+		approve("export", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: appstudioredhatcomv1alpha1.CloneReference{Kind: "ApplicationExport", Namespace: "bar", Name: "appfoo-clone", Generation: 2, UID: "uid-2"}, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		_, err := CheckApproval(context.Background(), source, applicationClone, from, now)
+		Expect(err).To(MatchError(ErrPendingApproval))
+
+		approval, err := CheckApproval(context.Background(), source, appstudioredhatcomv1alpha1.CloneReference{Kind: "ApplicationExport", Namespace: "bar", Name: "appfoo-clone", Generation: 2, UID: "uid-2"}, from, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approval.Name).To(Equal("export"))
+	})
+
+	It("Should wait for an approval of the namespace protected bundles were exported from", func() {
+		testScheme := runtime.NewScheme()
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		application := &hasApplicationAPI.Application{}
+		Expect(source.Get(context.Background(), types.NamespacedName{Namespace: "foo", Name: "appfoo"}, application)).To(Succeed())
+		// bundles are served from the namespace they are cloned into
+		bundleFrom := appstudioredhatcomv1alpha1.From{Namespace: "bar", Name: "appfoo"}
+
+		reader := bundle.New(application).Reader(testScheme, "bar")
+		_, err := CheckBundleApproval(context.Background(), reader, source, applicationClone, bundleFrom, now)
+		Expect(err).To(MatchError(ContainSubstring("application appfoo of namespace foo is protected")))
+
+		approve("approved", appstudioredhatcomv1alpha1.ApplicationCloneApprovalSpec{
+			Application: "appfoo", Clone: applicationClone, Approver: "alice", ExpirationTime: metav1.NewTime(now.Add(time.Hour)),
+		})
+		approval, err := CheckBundleApproval(context.Background(), reader, source, applicationClone, bundleFrom, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approval.Name).To(Equal("approved"))
+
+		// the approvals of a bundle that doesn't record where it comes from can't be found
+		application.Namespace = ""
+		_, err = CheckBundleApproval(context.Background(), bundle.New(application).Reader(testScheme, "bar"), source, applicationClone, bundleFrom, now)
+		Expect(err).To(MatchError("application appfoo of the bundle is protected, but the bundle doesn't record the namespace it was exported from"))
+
+		unprotected := bundle.New(&hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "appfoo"}}).Reader(testScheme, "bar")
+		Expect(CheckBundleApproval(context.Background(), unprotected, source, applicationClone, bundleFrom, now)).To(BeNil())
+	})
+})
@@ -0,0 +1,137 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+)
+
+// listSnapshotEnvironmentBindings returns the SnapshotEnvironmentBindings of the given Application.
+func listSnapshotEnvironmentBindings(ctx context.Context, c client.Reader, namespace, application string) ([]hasApplicationAPI.SnapshotEnvironmentBinding, error) {
+	bindingList := &hasApplicationAPI.SnapshotEnvironmentBindingList{}
+	err := c.List(ctx, bindingList, &client.ListOptions{Namespace: namespace})
+	if err != nil {
+		return nil, fmt.Errorf("error listing snapshotenvironmentbindings: %w", err)
+	}
+
+	var bindings []hasApplicationAPI.SnapshotEnvironmentBinding
+	for _, binding := range bindingList.Items {
+		if binding.Spec.Application == application {
+			bindings = append(bindings, binding)
+		}
+	}
+
+	return bindings, nil
+}
+
+// cloneSnapshotEnvironmentBinding returns a copy of the source binding that ties the cloned Application
+// to the clone of its Environment. environments and components map the names of the source Environments and
+// Components to the names of their clones. The component configuration (replicas, resources, env) is carried
+// over for the cloned Components, and the values of the env vars named in rewriteEnv that name a source Environment
+// or Component are rewritten to the new name. The source Environment name is replaced in the binding name, so that
+// "billing-app-staging" bound to "clone-staging" becomes "billing-app-clone-staging"; the new name is appended
+// when the binding name doesn't hold the source one.
+func cloneSnapshotEnvironmentBinding(binding *hasApplicationAPI.SnapshotEnvironmentBinding, namespace, application string, environments, components map[string]string, rewriteEnv []string) *hasApplicationAPI.SnapshotEnvironmentBinding {
+	spec := binding.Spec.DeepCopy()
+	environment := environments[binding.Spec.Environment]
+
+	spec.Application = application
+	spec.Environment = environment
+
+	rewrite := map[string]bool{}
+	for _, name := range rewriteEnv {
+		rewrite[name] = true
+	}
+
+	spec.Components = nil
+	for _, component := range binding.Spec.Components {
+		name, ok := components[component.Name]
+		if !ok {
+			continue
+		}
+		component = *component.DeepCopy()
+		component.Name = name
+		for i, env := range component.Configuration.Env {
+			if !rewrite[env.Name] {
+				continue
+			}
+			if renamed, ok := environments[env.Value]; ok {
+				component.Configuration.Env[i].Value = renamed
+			} else if renamed, ok := components[env.Value]; ok {
+				component.Configuration.Env[i].Value = renamed
+			}
+		}
+		spec.Components = append(spec.Components, component)
+	}
+
+	return &hasApplicationAPI.SnapshotEnvironmentBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bindingName(binding.Name, binding.Spec.Environment, environment),
+			Namespace: namespace,
+		},
+		Spec: *spec,
+	}
+}
+
+// bindingName returns the name of the clone of a binding: the source name with the dash-separated source
+// Environment name replaced, or with the Environment name appended if it doesn't hold it.
+func bindingName(name, source, environment string) string {
+	if source == environment {
+		return name
+	}
+	pattern := regexp.MustCompile(`(^|-)` + regexp.QuoteMeta(source) + `(-|$)`)
+	if loc := pattern.FindStringSubmatchIndex(name); loc != nil {
+		// keep the separators around the Environment name
+		return name[:loc[3]] + environment + name[loc[4]:]
+	}
+	return name + "-" + environment
+}
+
+// cloneSnapshot returns a copy of the source Snapshot for the cloned Application, so that the cloned
+// bindings deploy the same container images. components maps the names of the source Components to the names of
+// their clones: the Components that aren't cloned, e.g. in the Skip mode, are left out of the copy.
+func cloneSnapshot(snapshot *hasApplicationAPI.Snapshot, namespace, application string, components map[string]string) *hasApplicationAPI.Snapshot {
+	spec := snapshot.Spec.DeepCopy()
+
+	spec.Application = application
+
+	spec.Components = nil
+	for _, component := range snapshot.Spec.Components {
+		name, ok := components[component.Name]
+		if !ok {
+			continue
+		}
+		component = *component.DeepCopy()
+		component.Name = name
+		spec.Components = append(spec.Components, component)
+	}
+
+	return &hasApplicationAPI.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snapshot.Name,
+			Namespace: namespace,
+		},
+		Spec: *spec,
+	}
+}
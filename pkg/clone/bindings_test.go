@@ -0,0 +1,136 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+)
+
+var _ = Describe("SnapshotEnvironmentBinding cloning", func() {
+
+	It("Should bind the cloned Application to the cloned Environment", func() {
+		replicas := 3
+		binding := &hasApplicationAPI.SnapshotEnvironmentBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "appfoo-staging-binding", Namespace: "foo"},
+			Spec: hasApplicationAPI.SnapshotEnvironmentBindingSpec{
+				Application: "appfoo",
+				Environment: "staging",
+				Snapshot:    "appfoo-snapshot",
+				Components: []hasApplicationAPI.BindingComponent{
+					{
+						Name: "c1",
+						Configuration: hasApplicationAPI.BindingComponentConfiguration{
+							Replicas: &replicas,
+							Env:      []hasApplicationAPI.EnvVarPair{{Name: "LOG_LEVEL", Value: "debug"}},
+						},
+					},
+				},
+			},
+		}
+
+		cloned := cloneSnapshotEnvironmentBinding(binding, "bar", "appfoo", map[string]string{"staging": "clone-staging"}, map[string]string{"c1": "c1"}, nil)
+		Expect(cloned.Name).To(Equal("appfoo-clone-staging-binding"))
+		Expect(cloned.Namespace).To(Equal("bar"))
+		Expect(cloned.Spec.Environment).To(Equal("clone-staging"))
+		Expect(cloned.Spec.Snapshot).To(Equal("appfoo-snapshot"))
+		Expect(cloned.Spec.Components).To(Equal(binding.Spec.Components))
+
+		*cloned.Spec.Components[0].Configuration.Replicas = 1
+		Expect(*binding.Spec.Components[0].Configuration.Replicas).To(Equal(3))
+	})
+
+	It("Should rewrite the component configuration for the new names", func() {
+		binding := &hasApplicationAPI.SnapshotEnvironmentBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "foo"},
+			Spec: hasApplicationAPI.SnapshotEnvironmentBindingSpec{
+				Application: "appfoo",
+				Environment: "staging",
+				Snapshot:    "appfoo-snapshot",
+				Components: []hasApplicationAPI.BindingComponent{
+					{
+						Name: "frontend",
+						Configuration: hasApplicationAPI.BindingComponentConfiguration{
+							Env: []hasApplicationAPI.EnvVarPair{
+								{Name: "BACKEND", Value: "backend"},
+								{Name: "ENVIRONMENT", Value: "staging"},
+								{Name: "LOG_LEVEL", Value: "debug"},
+								{Name: "MODE", Value: "staging"},
+							},
+						},
+					},
+					{Name: "backend"},
+					{Name: "skipped"},
+				},
+			},
+		}
+		environments := map[string]string{"staging": "clone-staging"}
+		components := map[string]string{"frontend": "clone-frontend", "backend": "clone-backend"}
+
+		cloned := cloneSnapshotEnvironmentBinding(binding, "bar", "appfoo", environments, components, []string{"BACKEND", "ENVIRONMENT"})
+		Expect(cloned.Name).To(Equal("clone-staging"))
+		Expect(cloned.Spec.Components).To(Equal([]hasApplicationAPI.BindingComponent{
+			{
+				Name: "clone-frontend",
+				Configuration: hasApplicationAPI.BindingComponentConfiguration{
+					Env: []hasApplicationAPI.EnvVarPair{
+						{Name: "BACKEND", Value: "clone-backend"},
+						{Name: "ENVIRONMENT", Value: "clone-staging"},
+						{Name: "LOG_LEVEL", Value: "debug"},
+						// only the listed variables are rewritten
+						{Name: "MODE", Value: "staging"},
+					},
+				},
+			},
+			{Name: "clone-backend"},
+		}))
+		Expect(binding.Spec.Components[0].Configuration.Env[0].Value).To(Equal("backend"))
+	})
+
+	It("Should name the cloned binding after the cloned Environment", func() {
+		Expect(bindingName("appfoo-staging-binding", "staging", "clone-staging")).To(Equal("appfoo-clone-staging-binding"))
+		Expect(bindingName("appfoo-staging", "staging", "qa")).To(Equal("appfoo-qa"))
+		Expect(bindingName("staging-appfoo", "staging", "qa")).To(Equal("qa-appfoo"))
+		// Only a dash-separated Environment name is replaced
+		Expect(bindingName("appfoo-prestaging", "staging", "qa")).To(Equal("appfoo-prestaging-qa"))
+		Expect(bindingName("appfoo-binding", "staging", "qa")).To(Equal("appfoo-binding-qa"))
+		Expect(bindingName("appfoo-staging", "staging", "staging")).To(Equal("appfoo-staging"))
+	})
+
+	It("Should copy the bound Snapshot", func() {
+		snapshot := &hasApplicationAPI.Snapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "appfoo-snapshot", Namespace: "foo"},
+			Spec: hasApplicationAPI.SnapshotSpec{
+				Application: "appfoo",
+				Components: []hasApplicationAPI.SnapshotComponent{
+					{Name: "c1", ContainerImage: "quay.io/foo/c1@sha256:abc"},
+					{Name: "c2", ContainerImage: "quay.io/foo/c2@sha256:def"},
+				},
+			},
+		}
+
+		// c2 is skipped
+		cloned := cloneSnapshot(snapshot, "bar", "appfoo", map[string]string{"c1": "c1"})
+		Expect(cloned.Name).To(Equal("appfoo-snapshot"))
+		Expect(cloned.Namespace).To(Equal("bar"))
+		Expect(cloned.Spec.Components).To(Equal(snapshot.Spec.Components[:1]))
+	})
+})
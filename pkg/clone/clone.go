@@ -0,0 +1,485 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clone turns the resources of a source Application into the resources of its clone. A Planner
+// computes the objects a clone is made of from an ApplicationCloneSpec, an Applier creates them. The
+// ApplicationClone controller is built on them, and so can other tools.
+package clone
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+const (
+	// SourceNamespaceLabel is set on cloned resources to the namespace of the source Application
+	SourceNamespaceLabel = "clone.appstudio.redhat.com/source-namespace"
+
+	// SourceApplicationLabel is set on cloned resources to the name of the source Application
+	SourceApplicationLabel = "clone.appstudio.redhat.com/source-application"
+
+	// CloneLabel is set on cloned resources to the name of the ApplicationClone that created them
+	CloneLabel = "clone.appstudio.redhat.com/clone"
+)
+
+// Planner computes the objects of a clone
+type Planner struct {
+	// Source reads the resources of the Application that is cloned
+	Source client.Reader
+
+	// Target reads the namespace the Application is cloned into, e.g. to resolve the Environments named
+	// by integration test overrides. Planned objects take precedence over the ones read from Target.
+	Target client.Reader
+
+	// Scheme knows the kinds of the cloned resources
+	Scheme *runtime.Scheme
+
+	// Build holds the defaults of the build settings of the Components cloned from source, which the settings
+	// of the ApplicationClone and of its Components override
+	Build *appstudioredhatcomv1alpha1.BuildSettings
+
+	// Unstructured plans unstructured objects, in which the fields of the source objects that the compiled API
+	// types lack are kept, so that they survive a newer version of the CRDs in the cluster. Source must be able
+	// to read unstructured objects.
+	Unstructured bool
+
+	// RESTMapper selects the version the unstructured objects are read and written in, e.g. the version the
+	// source cluster prefers as found through discovery. The version of the compiled types is used when unset.
+	RESTMapper meta.RESTMapper
+
+	// Registry holds the Cloners of the kinds that are cloned. NewRegistry() is used when unset.
+	Registry *Registry
+
+	// Sanitization holds the rules of the cluster-wide sanitization policy, which come before the rules of the
+	// ApplicationClone so that it can't relax them
+	Sanitization []appstudioredhatcomv1alpha1.SanitizationRule
+
+	// Policies are the ClonePolicies of the cluster. The image visibility they force takes precedence over the
+	// build settings, and Plan fails with a PolicyError when the planned objects break their rules.
+	Policies []appstudioredhatcomv1alpha1.ClonePolicy
+
+	// Clone is the name of the ApplicationClone the plan is made for, recorded in the CloneLabel of the
+	// planned objects. It is left out when unset.
+	Clone string
+}
+
+// Plan is the set of objects of a clone, in the order they are to be created in. The kind of every
+// object is set.
+type Plan struct {
+	Objects []client.Object
+
+	// sources are the source objects the planned objects are cloned from, nil for the objects planned from
+	// scratch
+	sources []client.Object
+
+	// cloners are the Cloners of the planned objects, nil for the objects a BaseCloner applies
+	cloners []Cloner
+
+	// labels are set on every planned object
+	labels map[string]string
+
+	// sanitized are the environment variables of the planned Components that sanitization rules matched
+	sanitized []appstudioredhatcomv1alpha1.SanitizedField
+
+	// placeholders are the names of the Secrets planned as placeholders, without their values
+	placeholders map[string]bool
+}
+
+// Placeholder tells whether the planned object is a Secret the plan clones as a placeholder, without its values
+func (p *Plan) Placeholder(obj client.Object) bool {
+	return obj.GetObjectKind().GroupVersionKind().GroupKind() == schema.GroupKind{Kind: "Secret"} && p.placeholders[obj.GetName()]
+}
+
+// placeholder records that the named Secret is planned as a placeholder
+func (p *Plan) placeholder(name string) {
+	if p.placeholders == nil {
+		p.placeholders = map[string]bool{}
+	}
+	p.placeholders[name] = true
+}
+
+// Sanitized returns the environment variables of the planned Components that sanitization rules matched
+func (p *Plan) Sanitized() []appstudioredhatcomv1alpha1.SanitizedField {
+	return p.sanitized
+}
+
+// Resources returns the status entries of the planned objects, their kinds and names
+func (p *Plan) Resources() []appstudioredhatcomv1alpha1.Resource {
+	var resources []appstudioredhatcomv1alpha1.Resource
+	for i, obj := range p.Objects {
+		resources = append(resources, p.applier(i).Status(obj))
+	}
+	return resources
+}
+
+// componentNames returns the names of the planned Components keyed by the names of the Components they are
+// cloned from
+func (p *Plan) componentNames() map[string]string {
+	names := map[string]string{}
+	for i, obj := range p.Objects {
+		if _, ok := obj.(*hasApplicationAPI.Component); ok && p.sources[i] != nil {
+			names[p.sources[i].GetName()] = obj.GetName()
+		}
+	}
+	return names
+}
+
+// objectApplier applies the planned objects and reports them in the status, see Cloner
+type objectApplier interface {
+	Apply(ctx context.Context, c client.Client, obj client.Object) error
+	Status(obj client.Object) appstudioredhatcomv1alpha1.Resource
+}
+
+// applier returns what applies the i-th planned object: its Cloner, or a BaseCloner
+func (p *Plan) applier(i int) objectApplier {
+	if i < len(p.cloners) && p.cloners[i] != nil {
+		return p.cloners[i]
+	}
+	return BaseCloner{}
+}
+
+// add appends a copy of the object to the plan, unless an object of the same kind and name was already
+// planned: the first one wins, as it would when creating them. The provenance labels of the plan are
+// added to the object.
+func (p *Plan) add(scheme *runtime.Scheme, obj client.Object) error {
+	return p.addClone(scheme, obj, nil, nil)
+}
+
+// addClone adds the object cloned from the source object by the Cloner to the plan, see add.
+func (p *Plan) addClone(scheme *runtime.Scheme, obj, source client.Object, cloner Cloner) error {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return err
+	}
+
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	if p.find(obj, client.ObjectKeyFromObject(obj)) != nil {
+		return nil
+	}
+	if len(p.labels) > 0 {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		for k, v := range p.labels {
+			labels[k] = v
+		}
+		obj.SetLabels(labels)
+	}
+	p.Objects = append(p.Objects, obj)
+	if source != nil {
+		source = source.DeepCopyObject().(client.Object)
+	}
+	p.sources = append(p.sources, source)
+	p.cloners = append(p.cloners, cloner)
+	return nil
+}
+
+// provenance returns the labels recording where the objects of a clone come from. Values that aren't
+// valid label values, e.g. names longer than 63 characters, are left out.
+func provenance(clone, namespace string, from appstudioredhatcomv1alpha1.From) map[string]string {
+	labels := map[string]string{}
+	// a bundle is served from the namespace it is cloned into, which isn't a source
+	if from.Namespace != namespace {
+		labels[SourceNamespaceLabel] = from.Namespace
+		labels[SourceApplicationLabel] = from.Name
+	}
+	if clone != "" {
+		labels[CloneLabel] = clone
+	}
+
+	for k, v := range labels {
+		if len(validation.IsValidLabelValue(v)) > 0 {
+			delete(labels, k)
+		}
+	}
+	return labels
+}
+
+// find returns the planned object of the same kind as obj with the key. Objects whose kind is set are matched by
+// kind, so that a typed and an unstructured object of the same kind match; the others by type.
+func (p *Plan) find(obj client.Object, key client.ObjectKey) client.Object {
+	gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+	for _, o := range p.Objects {
+		if client.ObjectKeyFromObject(o) != key {
+			continue
+		}
+		if gk.Empty() && reflect.TypeOf(o) == reflect.TypeOf(obj) {
+			return o
+		}
+		if !gk.Empty() && o.GetObjectKind().GroupVersionKind().GroupKind() == gk {
+			return o
+		}
+	}
+	return nil
+}
+
+// getTarget reads an object of the target namespace, from the plan first and from the target otherwise.
+func (p *Planner) getTarget(ctx context.Context, plan *Plan, key client.ObjectKey, obj client.Object) error {
+	if o := plan.find(obj, key); o != nil {
+		reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(o.DeepCopyObject()).Elem())
+		return nil
+	}
+	return p.Target.Get(ctx, key, obj)
+}
+
+// Plan returns the objects cloning the Application of spec.From into the namespace. spec.From must
+// name the Application and its namespace in Source.
+func (p *Planner) Plan(ctx context.Context, namespace string, spec *appstudioredhatcomv1alpha1.ApplicationCloneSpec) (*Plan, error) {
+	log := ctrllog.FromContext(ctx)
+
+	from := spec.From
+	plan := &Plan{labels: provenance(p.Clone, namespace, from)}
+	req := &Request{Planner: p, Plan: plan, Namespace: namespace, Spec: spec}
+
+	registry := p.Registry
+	if registry == nil {
+		registry = NewRegistry()
+	}
+
+	rules, err := compileSanitizationRules(append(append([]appstudioredhatcomv1alpha1.SanitizationRule(nil), p.Sanitization...), spec.Sanitization...))
+	if err != nil {
+		return nil, err
+	}
+
+	// The Application and its Components
+	for _, gvk := range []schema.GroupVersionKind{
+		hasApplicationAPI.GroupVersion.WithKind("Application"),
+		hasApplicationAPI.GroupVersion.WithKind("Component"),
+	} {
+		cloner := registry.Cloner(gvk)
+		sources, err := cloner.Discover(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		err = p.cloneAll(ctx, req, cloner, sources)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The environment of the cloned Components is sanitized before what it refers to is cloned
+	for _, obj := range plan.Objects {
+		if component, ok := obj.(*hasApplicationAPI.Component); ok {
+			plan.sanitized = append(plan.sanitized, sanitize(component, rules)...)
+		}
+	}
+
+	// The ConfigMaps the cloned Components refer to
+	configMaps := envConfigMapCloner{}
+	configMapSources, err := configMaps.Discover(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	err = p.cloneAll(ctx, req, configMaps, configMapSources)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Secrets the cloned Components refer to
+	secrets := secretCloner{}
+	secretSources, err := secrets.Discover(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	err = p.cloneAll(ctx, req, secrets, secretSources)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Integration Tests are cloned once the Environments they run in are
+	integrationTests := registry.Cloner(integrationtestapi.GroupVersion.WithKind("IntegrationTestScenario"))
+	testSources, err := integrationTests.Discover(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	var selectedTests []integrationtestapi.IntegrationTestScenario
+	for _, obj := range testSources {
+		if integrationTest, ok := obj.(*integrationtestapi.IntegrationTestScenario); ok {
+			selectedTests = append(selectedTests, *integrationTest)
+		}
+	}
+
+	cloneEnvironments := spec.Environments != nil && spec.Environments.Clone
+	cloneBindings := spec.SnapshotEnvironmentBindings != nil && spec.SnapshotEnvironmentBindings.Clone
+
+	var bindings []hasApplicationAPI.SnapshotEnvironmentBinding
+	if cloneEnvironments || cloneBindings {
+		bindings, err = listSnapshotEnvironmentBindings(ctx, p.Source, from.Namespace, from.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Clone the Environments the Application uses
+	var sourceEnvironments []hasApplicationAPI.Environment
+	if cloneEnvironments {
+		sourceEnvironments, err = discoverEnvironments(ctx, p.Source, from.Namespace, selectedTests, bindings)
+		if err != nil {
+			return nil, fmt.Errorf("error discovering environments: %w", err)
+		}
+	}
+
+	req.Environments, err = environmentNames(spec.Environments, sourceEnvironments)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, environment := range sourceEnvironments {
+		if spec.Environments.IsMapped(environment.Name) {
+			continue
+		}
+		err = plan.addClone(p.Scheme, cloneEnvironment(&environment, namespace, req.Environments), &environment, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = p.cloneAll(ctx, req, integrationTests, testSources)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bind the cloned Application to the cloned Environments
+	if cloneBindings {
+		components := plan.componentNames()
+		for _, binding := range bindings {
+			if _, ok := req.Environments[binding.Spec.Environment]; !ok {
+				log.Info("Environment is neither cloned nor mapped, skipping SnapshotEnvironmentBinding", "binding", binding.Name, "environment", binding.Spec.Environment)
+				continue
+			}
+
+			snapshot := &hasApplicationAPI.Snapshot{}
+			err = p.Source.Get(ctx, types.NamespacedName{Namespace: binding.Namespace, Name: binding.Spec.Snapshot}, snapshot)
+			if err != nil {
+				return nil, fmt.Errorf("error reading snapshot %s: %w", binding.Spec.Snapshot, err)
+			}
+
+			err = plan.addClone(p.Scheme, cloneSnapshot(snapshot, namespace, from.Name, components), snapshot, nil)
+			if err != nil {
+				return nil, err
+			}
+			err = plan.addClone(p.Scheme, cloneSnapshotEnvironmentBinding(&binding, namespace, from.Name, req.Environments, components, spec.SnapshotEnvironmentBindings.RewriteEnv), &binding, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The extra resources, last as nothing refers to them
+	for _, resource := range spec.ExtraResources {
+		gvk, selector, err := extraResourceSelector(resource)
+		if err != nil {
+			return nil, err
+		}
+		cloner, err := registry.ExtraResourceCloner(gvk)
+		if err != nil {
+			return nil, err
+		}
+		req.Selector = selector
+		sources, err := cloner.Discover(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		err = p.cloneAll(ctx, req, cloner, sources)
+		if err != nil {
+			return nil, err
+		}
+	}
+	req.Selector = nil
+
+	if p.Unstructured {
+		err = p.unstructure(ctx, plan)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = checkPlanPolicies(p.Policies, plan)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// cloneAll plans the clones of the source objects
+func (p *Planner) cloneAll(ctx context.Context, req *Request, cloner Cloner, sources []client.Object) error {
+	for _, source := range sources {
+		obj, err := cloner.Transform(ctx, req, source)
+		if err != nil {
+			return err
+		}
+		if obj == nil {
+			continue
+		}
+		err = req.Plan.addClone(p.Scheme, obj, source, cloner)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applicationCloner clones the Application. Its display name and description are carried over; the repositories
+// of the source Application belong to it and are left for the clone to get its own.
+type applicationCloner struct {
+	BaseCloner
+}
+
+// Discover returns the source Application
+func (applicationCloner) Discover(ctx context.Context, req *Request) ([]client.Object, error) {
+	application := &hasApplicationAPI.Application{}
+	err := req.Planner.Source.Get(ctx, types.NamespacedName{Namespace: req.Spec.From.Namespace, Name: req.Spec.From.Name}, application)
+	if err != nil {
+		return nil, fmt.Errorf("error reading application %s: %w", req.Spec.From.Name, err)
+	}
+	return []client.Object{application}, nil
+}
+
+// Transform returns the Application in the target namespace
+func (applicationCloner) Transform(ctx context.Context, req *Request, source client.Object) (client.Object, error) {
+	application, ok := source.(*hasApplicationAPI.Application)
+	if !ok {
+		return nil, fmt.Errorf("expected an Application, got %T", source)
+	}
+	return &hasApplicationAPI.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      application.Name,
+			Namespace: req.Namespace,
+		},
+		Spec: hasApplicationAPI.ApplicationSpec{
+			DisplayName: application.Spec.DisplayName,
+			Description: application.Spec.Description,
+		},
+	}, nil
+}
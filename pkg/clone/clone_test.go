@@ -0,0 +1,192 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+var _ = Describe("Planner", func() {
+
+	var testScheme *runtime.Scheme
+	var source client.Client
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		integrationTest := newIntegrationTest("it1", "appfoo", nil)
+		integrationTest.Namespace = "foo"
+		integrationTest.Spec.Environment.Name = "staging"
+
+		source = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			newApplication("appfoo"),
+			&hasApplicationAPI.Component{
+				ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "foo"},
+				Spec: hasApplicationAPI.ComponentSpec{
+					ComponentName: "c1",
+					Application:   "appfoo",
+					Source: hasApplicationAPI.ComponentSource{
+						ComponentSourceUnion: hasApplicationAPI.ComponentSourceUnion{
+							GitSource: &hasApplicationAPI.GitSource{URL: "github.com/foo/c1"},
+						},
+					},
+				},
+			},
+			&hasApplicationAPI.Component{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-c1", Namespace: "foo"},
+				Spec:       hasApplicationAPI.ComponentSpec{ComponentName: "other-c1", Application: "otherapp"},
+			},
+			&integrationTest,
+			newEnvironment("staging", ""),
+		).Build()
+	})
+
+	It("Should plan the objects of the clone in order", func() {
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:             appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{{Name: "c1"}},
+			Environments:     &appstudioredhatcomv1alpha1.EnvironmentCloning{Clone: true, NamePrefix: "clone-"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(plan.Resources()).To(Equal([]appstudioredhatcomv1alpha1.Resource{
+			{Kind: "Application", Name: "appfoo"},
+			{Kind: "Component", Name: "c1"},
+			{Kind: "Environment", Name: "clone-staging"},
+			{Kind: "IntegrationTestScenario", Name: "it1"},
+		}))
+		for _, obj := range plan.Objects {
+			Expect(obj.GetNamespace()).To(Equal("bar"))
+		}
+
+		application := plan.Objects[0].(*hasApplicationAPI.Application)
+		Expect(application.Spec.DisplayName).To(Equal("appfoo"))
+		component := plan.Objects[1].(*hasApplicationAPI.Component)
+		Expect(component.Spec.Source.GitSource.URL).To(Equal("github.com/foo/c1"))
+		integrationTest := plan.Objects[3].(*integrationtestapi.IntegrationTestScenario)
+		Expect(integrationTest.Spec.Environment.Name).To(Equal("clone-staging"))
+	})
+
+	It("Should label the planned objects with their provenance", func() {
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme, Clone: "appfoo-clone"}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:             appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{{Name: "c1"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		for _, obj := range plan.Objects {
+			Expect(obj.GetLabels()).To(HaveKeyWithValue(CloneLabel, "appfoo-clone"))
+			Expect(obj.GetLabels()).To(HaveKeyWithValue(SourceNamespaceLabel, "foo"))
+			Expect(obj.GetLabels()).To(HaveKeyWithValue(SourceApplicationLabel, "appfoo"))
+		}
+
+		// a bundle is read from the namespace it is cloned into
+		plan, err = planner.Plan(context.Background(), "foo", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From: appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Objects[0].GetLabels()).To(Equal(map[string]string{CloneLabel: "appfoo-clone"}))
+	})
+
+	It("Should resolve overridden Environments from the plan and the target", func() {
+		target := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(&hasApplicationAPI.Environment{
+			ObjectMeta: metav1.ObjectMeta{Name: "ephemeral", Namespace: "bar"},
+			Spec:       hasApplicationAPI.EnvironmentSpec{Type: "POC"},
+		}).Build()
+		planner := &Planner{Source: source, Target: target, Scheme: testScheme}
+
+		spec := &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:                     appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			Environments:             &appstudioredhatcomv1alpha1.EnvironmentCloning{Clone: true, NamePrefix: "clone-"},
+			IntegrationTestOverrides: []appstudioredhatcomv1alpha1.IntegrationTestOverride{{Name: "it1", Environment: "clone-staging"}},
+		}
+		plan, err := planner.Plan(context.Background(), "bar", spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Objects[len(plan.Objects)-1].(*integrationtestapi.IntegrationTestScenario).Spec.Environment.Name).To(Equal("clone-staging"))
+
+		spec.IntegrationTestOverrides[0].Environment = "ephemeral"
+		plan, err = planner.Plan(context.Background(), "bar", spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Objects[len(plan.Objects)-1].(*integrationtestapi.IntegrationTestScenario).Spec.Environment.Type).To(BeEquivalentTo("POC"))
+
+		spec.IntegrationTestOverrides[0].Environment = "missing"
+		_, err = planner.Plan(context.Background(), "bar", spec)
+		Expect(err).To(MatchError(ContainSubstring("error reading environment missing")))
+	})
+
+	It("Should read planned objects back", func() {
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newEnvironment("production", "")).Build(), Scheme: testScheme}
+		plan := &Plan{}
+
+		Expect(plan.add(testScheme, newEnvironment("development", ""))).To(Succeed())
+		Expect(plan.add(testScheme, newEnvironment("development", "staging"))).To(Succeed())
+		Expect(plan.Objects).To(HaveLen(1))
+
+		environment := &hasApplicationAPI.Environment{}
+		Expect(planner.getTarget(context.Background(), plan, types.NamespacedName{Namespace: "foo", Name: "development"}, environment)).To(Succeed())
+		Expect(environment.Kind).To(Equal("Environment"))
+		Expect(environment.Spec.ParentEnvironment).To(BeEmpty())
+		Expect(planner.getTarget(context.Background(), plan, types.NamespacedName{Namespace: "foo", Name: "production"}, environment)).To(Succeed())
+		Expect(plan.Objects).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("Applier", func() {
+
+	It("Should create the planned objects and leave existing ones", func() {
+		testScheme := runtime.NewScheme()
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+
+		target := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(&hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "bar"},
+			Spec:       hasApplicationAPI.ComponentSpec{ContainerImage: "quay.io/bar/c1"},
+		}).Build()
+
+		plan := &Plan{}
+		Expect(plan.add(testScheme, &hasApplicationAPI.Application{ObjectMeta: metav1.ObjectMeta{Name: "appfoo", Namespace: "bar"}})).To(Succeed())
+		Expect(plan.add(testScheme, &hasApplicationAPI.Component{
+			ObjectMeta: metav1.ObjectMeta{Name: "c1", Namespace: "bar"},
+			Spec:       hasApplicationAPI.ComponentSpec{ContainerImage: "quay.io/foo/c1"},
+		})).To(Succeed())
+
+		applier := &Applier{Client: target}
+		Expect(applier.Apply(context.Background(), plan)).To(Succeed())
+
+		Expect(target.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "appfoo"}, &hasApplicationAPI.Application{})).To(Succeed())
+		component := &hasApplicationAPI.Component{}
+		Expect(target.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "c1"}, component)).To(Succeed())
+		Expect(component.Spec.ContainerImage).To(Equal("quay.io/bar/c1"))
+	})
+})
@@ -0,0 +1,280 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+// Cloner clones the objects of one kind that belong to an Application
+type Cloner interface {
+	// Discover returns the objects of the source namespace to clone
+	Discover(ctx context.Context, req *Request) ([]client.Object, error)
+
+	// Transform returns the clone of the source object in the target namespace, or nil to leave it out
+	Transform(ctx context.Context, req *Request, source client.Object) (client.Object, error)
+
+	// Apply creates the cloned object
+	Apply(ctx context.Context, c client.Client, obj client.Object) error
+
+	// Status returns the entry of the cloned object in the status of the ApplicationClone
+	Status(obj client.Object) appstudioredhatcomv1alpha1.Resource
+}
+
+// Request is the clone of an Application that Cloners take part in
+type Request struct {
+	// Planner plans the clone
+	Planner *Planner
+
+	// Plan holds the objects planned so far
+	Plan *Plan
+
+	// Namespace is the namespace the Application is cloned into
+	Namespace string
+
+	// Spec is the spec of the ApplicationClone. Spec.From names the source Application.
+	Spec *appstudioredhatcomv1alpha1.ApplicationCloneSpec
+
+	// Environments maps the names of the Environments of the source namespace to the names of their clones
+	// or of the Environments they are mapped to
+	Environments map[string]string
+
+	// Selector selects the objects of an extra resource, nil for the other kinds
+	Selector labels.Selector
+
+	// snapshot is the Snapshot the Components in the Snapshot mode take their image from, once read
+	snapshot *hasApplicationAPI.Snapshot
+}
+
+// BaseCloner implements the Apply and Status methods of a Cloner for objects that are created as they are
+// planned and reported by their kind and name.
+type BaseCloner struct{}
+
+// Apply creates the object, unless it already exists
+func (BaseCloner) Apply(ctx context.Context, c client.Client, obj client.Object) error {
+	log := ctrllog.FromContext(ctx)
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+
+	err := c.Create(ctx, obj.DeepCopyObject().(client.Object))
+	if errors.IsAlreadyExists(err) {
+		log.Info("already exists, skipping", "kind", kind, "name", obj.GetName())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Info("created", "kind", kind, "name", obj.GetName())
+	return nil
+}
+
+// Status returns the kind and name of the object
+func (BaseCloner) Status(obj client.Object) appstudioredhatcomv1alpha1.Resource {
+	return appstudioredhatcomv1alpha1.Resource{
+		Kind: obj.GetObjectKind().GroupVersionKind().Kind,
+		Name: obj.GetName(),
+	}
+}
+
+// Registry holds the Cloners by the kind of the objects they clone, and the kinds spec.extraResources may select
+type Registry struct {
+	cloners        map[schema.GroupVersionKind]Cloner
+	extraResources map[schema.GroupKind]bool
+}
+
+// NewRegistry returns a Registry holding the Cloners of the built-in kinds: Applications, Components and
+// IntegrationTestScenarios, along with Services and Routes without the fields the cluster assigns them. The node
+// ports of Services are left out too, as they are unique in the cluster.
+// ConfigMaps, Services and Routes are allowed as extra resources.
+func NewRegistry() *Registry {
+	r := &Registry{cloners: map[schema.GroupVersionKind]Cloner{}, extraResources: map[schema.GroupKind]bool{}}
+	r.Register(hasApplicationAPI.GroupVersion.WithKind("Application"), applicationCloner{})
+	r.Register(hasApplicationAPI.GroupVersion.WithKind("Component"), componentCloner{})
+	r.Register(integrationtestapi.GroupVersion.WithKind("IntegrationTestScenario"), integrationTestCloner{})
+
+	service := schema.GroupVersionKind{Version: "v1", Kind: "Service"}
+	r.Register(service, &UnstructuredCloner{GVK: service, Strip: [][]string{
+		{"spec", "clusterIP"}, {"spec", "clusterIPs"}, {"spec", "ports", "*", "nodePort"}, {"spec", "healthCheckNodePort"},
+	}})
+	route := schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+	r.Register(route, &UnstructuredCloner{GVK: route, Strip: [][]string{{"spec", "host"}}})
+
+	for _, gk := range []schema.GroupKind{{Kind: "ConfigMap"}, service.GroupKind(), route.GroupKind()} {
+		r.extraResources[gk] = true
+	}
+	return r
+}
+
+// Register sets the Cloner of the kind, replacing the one registered before
+func (r *Registry) Register(gvk schema.GroupVersionKind, cloner Cloner) {
+	r.cloners[gvk] = cloner
+}
+
+// Cloner returns the Cloner of the kind. Kinds without a registered Cloner are cloned as unstructured objects.
+func (r *Registry) Cloner(gvk schema.GroupVersionKind) Cloner {
+	if cloner, ok := r.cloners[gvk]; ok {
+		return cloner
+	}
+	return &UnstructuredCloner{GVK: gvk}
+}
+
+// AllowExtraResource lets spec.extraResources select the objects of the kind. Secrets can't be allowed: they are
+// cloned through the strategies of spec.secrets only.
+func (r *Registry) AllowExtraResource(gk schema.GroupKind) error {
+	if isSecret(gk) {
+		return fmt.Errorf("Secrets can't be cloned as extra resources")
+	}
+	r.extraResources[gk] = true
+	return nil
+}
+
+// ExtraResourceCloner returns the Cloner of a kind selected by spec.extraResources, once it is allowed
+func (r *Registry) ExtraResourceCloner(gvk schema.GroupVersionKind) (Cloner, error) {
+	if isSecret(gvk.GroupKind()) {
+		return nil, fmt.Errorf("Secrets can't be cloned as extra resources, list them in secrets instead")
+	}
+	if !r.extraResources[gvk.GroupKind()] {
+		return nil, fmt.Errorf("%s can't be cloned as an extra resource, the controller only allows %s", gvk.GroupKind(), r.extraResourceKinds())
+	}
+	return r.Cloner(gvk), nil
+}
+
+// extraResourceKinds lists the kinds allowed as extra resources, sorted
+func (r *Registry) extraResourceKinds() string {
+	var kinds []string
+	for gk := range r.extraResources {
+		kinds = append(kinds, gk.String())
+	}
+	sort.Strings(kinds)
+	return strings.Join(kinds, ", ")
+}
+
+// isSecret tells whether the kind is the core Secret
+func isSecret(gk schema.GroupKind) bool {
+	return gk == schema.GroupKind{Kind: "Secret"}
+}
+
+// UnstructuredCloner clones the objects of any kind that the selector of the request selects, as they are. Only
+// their name, labels and annotations are kept from their metadata, and their status is left out.
+type UnstructuredCloner struct {
+	BaseCloner
+
+	// GVK is the kind of the cloned objects
+	GVK schema.GroupVersionKind
+
+	// Strip lists the paths of the fields left out of the clones, e.g. the ones the cluster assigns. A "*" stands
+	// for every item of a list.
+	Strip [][]string
+}
+
+// Discover lists the objects of the source namespace selected by the request
+func (c *UnstructuredCloner) Discover(ctx context.Context, req *Request) ([]client.Object, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(c.GVK.GroupVersion().WithKind(c.GVK.Kind + "List"))
+	err := req.Planner.Source.List(ctx, list, &client.ListOptions{Namespace: req.Spec.From.Namespace, LabelSelector: req.Selector})
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s: %w", strings.ToLower(c.GVK.Kind), err)
+	}
+
+	var objects []client.Object
+	for i := range list.Items {
+		objects = append(objects, &list.Items[i])
+	}
+	return objects, nil
+}
+
+// Transform copies the object into the target namespace
+func (c *UnstructuredCloner) Transform(ctx context.Context, req *Request, source client.Object) (client.Object, error) {
+	u := source.(*unstructured.Unstructured).DeepCopy()
+	delete(u.Object, "status")
+	for _, path := range c.Strip {
+		removeField(u.Object, path)
+	}
+
+	u.Object["metadata"] = map[string]interface{}{}
+	u.SetGroupVersionKind(c.GVK)
+	u.SetName(source.GetName())
+	u.SetNamespace(req.Namespace)
+	u.SetLabels(source.GetLabels())
+	u.SetAnnotations(clonedAnnotations(source.GetAnnotations()))
+	return u, nil
+}
+
+// removeField removes the field at the path from the object, from every item of the lists a "*" stands for
+func removeField(obj map[string]interface{}, path []string) {
+	for i, field := range path {
+		if field != "*" {
+			continue
+		}
+		items, found, err := unstructured.NestedSlice(obj, path[:i]...)
+		if !found || err != nil {
+			return
+		}
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				removeField(m, path[i+1:])
+			}
+		}
+		// NestedSlice returns a deep copy of the list
+		_ = unstructured.SetNestedSlice(obj, items, path[:i]...)
+		return
+	}
+	unstructured.RemoveNestedField(obj, path...)
+}
+
+// clonedAnnotations returns the annotations a clone keeps from its source object: all of them but the one
+// kubectl apply records the source object in, with the fields the clone leaves out and the values of Secrets.
+func clonedAnnotations(annotations map[string]string) map[string]string {
+	if annotations == nil {
+		return nil
+	}
+	cloned := map[string]string{}
+	for k, v := range annotations {
+		if k != corev1.LastAppliedConfigAnnotation {
+			cloned[k] = v
+		}
+	}
+	return cloned
+}
+
+// extraResourceSelector returns the GroupVersionKind and the selector of the extra resource
+func extraResourceSelector(resource appstudioredhatcomv1alpha1.ExtraResource) (schema.GroupVersionKind, labels.Selector, error) {
+	gv, err := schema.ParseGroupVersion(resource.APIVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, nil, fmt.Errorf("error parsing apiVersion %s: %w", resource.APIVersion, err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&resource.Selector)
+	if err != nil {
+		return schema.GroupVersionKind{}, nil, fmt.Errorf("error parsing the selector of %s: %w", resource.Kind, err)
+	}
+	return gv.WithKind(resource.Kind), selector, nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+// suffixCloner clones Components under a suffixed name, and reports them as such
+type suffixCloner struct {
+	componentCloner
+	applied []string
+}
+
+func (c *suffixCloner) Transform(ctx context.Context, req *Request, source client.Object) (client.Object, error) {
+	obj, err := c.componentCloner.Transform(ctx, req, source)
+	if obj != nil {
+		obj.SetName(obj.GetName() + "-clone")
+	}
+	return obj, err
+}
+
+func (c *suffixCloner) Apply(ctx context.Context, cl client.Client, obj client.Object) error {
+	c.applied = append(c.applied, obj.GetName())
+	return c.componentCloner.Apply(ctx, cl, obj)
+}
+
+func (c *suffixCloner) Status(obj client.Object) appstudioredhatcomv1alpha1.Resource {
+	return appstudioredhatcomv1alpha1.Resource{Kind: "SuffixedComponent", Name: obj.GetName()}
+}
+
+var _ = Describe("Cloners", func() {
+
+	var testScheme *runtime.Scheme
+	var source client.Client
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		source = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			newApplication("appfoo"),
+			newComponent("c1", "quay.io/foo/c1:latest"),
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "appfoo-config",
+					Namespace:       "foo",
+					Labels:          map[string]string{"app.kubernetes.io/part-of": "appfoo"},
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "Pod", Name: "p", UID: "1234"}},
+				},
+				Data: map[string]string{"LOG_LEVEL": "debug"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "other-config", Namespace: "foo"},
+			},
+			&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "appfoo-config",
+					Namespace: "foo",
+					Labels:    map[string]string{"app.kubernetes.io/part-of": "appfoo"},
+					Annotations: map[string]string{
+						"description":                      "billing API",
+						corev1.LastAppliedConfigAnnotation: `{"apiVersion":"v1","kind":"Service"}`,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Type:                  corev1.ServiceTypeLoadBalancer,
+					Ports:                 []corev1.ServicePort{{Port: 8080, NodePort: 30080}},
+					ClusterIP:             "10.0.0.1",
+					ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+					HealthCheckNodePort:   30081,
+				},
+				Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "1.2.3.4"}}}},
+			},
+		).Build()
+	})
+
+	It("Should clone the selected objects of the extra resources", func() {
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme, Clone: "appfoo-clone"}
+		selector := metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/part-of": "appfoo"}}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From: appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			ExtraResources: []appstudioredhatcomv1alpha1.ExtraResource{
+				{APIVersion: "v1", Kind: "ConfigMap", Selector: selector},
+				{APIVersion: "v1", Kind: "Service", Selector: selector},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Resources()).To(Equal([]appstudioredhatcomv1alpha1.Resource{
+			{Kind: "Application", Name: "appfoo"},
+			{Kind: "Component", Name: "c1"},
+			{Kind: "ConfigMap", Name: "appfoo-config"},
+			{Kind: "Service", Name: "appfoo-config"},
+		}))
+
+		configMap := plan.Objects[2].(*unstructured.Unstructured)
+		Expect(configMap.GetNamespace()).To(Equal("bar"))
+		Expect(configMap.GetOwnerReferences()).To(BeEmpty())
+		Expect(configMap.GetResourceVersion()).To(BeEmpty())
+		Expect(configMap.GetLabels()).To(HaveKeyWithValue("app.kubernetes.io/part-of", "appfoo"))
+		Expect(configMap.GetLabels()).To(HaveKeyWithValue(CloneLabel, "appfoo-clone"))
+		Expect(configMap.Object["data"]).To(Equal(map[string]interface{}{"LOG_LEVEL": "debug"}))
+		service := plan.Objects[3].(*unstructured.Unstructured)
+		Expect(service.Object).NotTo(HaveKey("status"))
+		Expect(service.Object["spec"]).NotTo(HaveKey("clusterIP"))
+		Expect(service.Object["spec"]).NotTo(HaveKey("healthCheckNodePort"))
+		Expect(service.Object["spec"]).To(HaveKeyWithValue("ports", []interface{}{map[string]interface{}{"port": int64(8080), "targetPort": int64(0)}}))
+		Expect(service.GetAnnotations()).To(Equal(map[string]string{"description": "billing API"}))
+
+		target := fake.NewClientBuilder().WithScheme(testScheme).Build()
+		Expect((&Applier{Client: target}).Apply(context.Background(), plan)).To(Succeed())
+		Expect(target.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "appfoo-config"}, &corev1.ConfigMap{})).To(Succeed())
+		Expect(target.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "appfoo-config"}, &corev1.Service{})).To(Succeed())
+	})
+
+	It("Should report invalid extra resources", func() {
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+		_, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From: appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			ExtraResources: []appstudioredhatcomv1alpha1.ExtraResource{{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Selector:   metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Near"}}},
+			}},
+		})
+		Expect(err).To(MatchError(ContainSubstring("error parsing the selector of ConfigMap")))
+	})
+
+	It("Should plan, apply and report the objects through the registered Cloners", func() {
+		cloner := &suffixCloner{}
+		registry := NewRegistry()
+		registry.Register(hasApplicationAPI.GroupVersion.WithKind("Component"), cloner)
+
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme, Registry: registry}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From: appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Resources()).To(Equal([]appstudioredhatcomv1alpha1.Resource{
+			{Kind: "Application", Name: "appfoo"},
+			{Kind: "SuffixedComponent", Name: "c1-clone"},
+		}))
+
+		target := fake.NewClientBuilder().WithScheme(testScheme).Build()
+		Expect((&Applier{Client: target}).Apply(context.Background(), plan)).To(Succeed())
+		Expect(cloner.applied).To(Equal([]string{"c1-clone"}))
+		Expect(target.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "c1-clone"}, &hasApplicationAPI.Component{})).To(Succeed())
+	})
+
+	It("Should clone the kinds without a registered Cloner as unstructured objects", func() {
+		gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+		Expect(NewRegistry().Cloner(gvk)).To(Equal(&UnstructuredCloner{GVK: gvk}))
+	})
+
+	It("Should only clone the allowed kinds as extra resources", func() {
+		registry := NewRegistry()
+		widget := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+		_, err := registry.ExtraResourceCloner(widget)
+		Expect(err).To(MatchError("Widget.example.com can't be cloned as an extra resource, the controller only allows ConfigMap, Route.route.openshift.io, Service"))
+		Expect(registry.AllowExtraResource(widget.GroupKind())).To(Succeed())
+		Expect(registry.ExtraResourceCloner(widget)).To(Equal(&UnstructuredCloner{GVK: widget}))
+
+		Expect(registry.AllowExtraResource(schema.GroupKind{Kind: "Secret"})).NotTo(Succeed())
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme, Registry: registry}
+		_, err = planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:           appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			ExtraResources: []appstudioredhatcomv1alpha1.ExtraResource{{APIVersion: "v1", Kind: "Secret"}},
+		})
+		Expect(err).To(MatchError("Secrets can't be cloned as extra resources, list them in secrets instead"))
+	})
+})
@@ -0,0 +1,269 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+const (
+	// ImageGenerateAnnotation asks the build service to generate the image repository of a Component
+	ImageGenerateAnnotation = "image.redhat.com/generate"
+
+	// SkipInitialChecksAnnotation keeps the build service from building a Component once it is created
+	SkipInitialChecksAnnotation = "skip-initial-checks"
+)
+
+// componentCloner clones the Components of the Application, each in its mode
+type componentCloner struct {
+	BaseCloner
+}
+
+// Discover lists the Components of the source Application
+func (componentCloner) Discover(ctx context.Context, req *Request) ([]client.Object, error) {
+	log := ctrllog.FromContext(ctx)
+	from := req.Spec.From
+
+	componentList := &hasApplicationAPI.ComponentList{}
+	err := req.Planner.Source.List(ctx, componentList, &client.ListOptions{Namespace: from.Namespace})
+	if err != nil {
+		return nil, fmt.Errorf("error listing components: %w", err)
+	}
+
+	var components []client.Object
+	for i, c := range componentList.Items {
+		if c.Spec.Application != from.Name {
+			continue
+		}
+		log.Info("found Component", c.Namespace, c.Name)
+		components = append(components, &componentList.Items[i])
+	}
+	return components, nil
+}
+
+// Transform clones the Component in its mode, or leaves it out in the Skip mode
+func (componentCloner) Transform(ctx context.Context, req *Request, source client.Object) (client.Object, error) {
+	log := ctrllog.FromContext(ctx)
+	c := source.(*hasApplicationAPI.Component)
+	spec, from := req.Spec, req.Spec.From
+
+	switch mode := componentMode(spec, c.Name); mode {
+	case appstudioredhatcomv1alpha1.ComponentModeSource:
+		if c.Spec.Source.GitSource == nil {
+			return nil, fmt.Errorf("error cloning component %s: it has no Git source to build from, clone it in the Image or Snapshot mode", c.Name)
+		}
+		var build *appstudioredhatcomv1alpha1.BuildSettings
+		if source := componentSource(spec, c.Name); source != nil {
+			build = source.Build
+		}
+		return cloneSourceComponent(c, req.Namespace, from.Name, buildAnnotations(req.Planner.Build, spec.Build, build, forcedBuildSettings(req.Planner.Policies))), nil
+	case appstudioredhatcomv1alpha1.ComponentModeImage:
+		return cloneImageComponent(c, req.Namespace, from.Name, c.Spec.ContainerImage), nil
+	case appstudioredhatcomv1alpha1.ComponentModeSnapshot:
+		if req.snapshot == nil {
+			snapshot, err := getSnapshot(ctx, req.Planner.Source, from.Namespace, from.Name, spec.Snapshot)
+			if err != nil {
+				return nil, err
+			}
+			req.snapshot = snapshot
+		}
+		image, err := snapshotImage(req.snapshot, c.Name)
+		if err != nil {
+			return nil, err
+		}
+		return cloneImageComponent(c, req.Namespace, from.Name, image), nil
+	case appstudioredhatcomv1alpha1.ComponentModeSkip:
+		log.Info("skipping Component", "component", c.Name)
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("error cloning component %s: unknown mode %q", c.Name, mode)
+	}
+}
+
+// componentSource returns the entry of the named Component in ComponentSources, or nil if it isn't listed.
+func componentSource(spec *appstudioredhatcomv1alpha1.ApplicationCloneSpec, name string) *appstudioredhatcomv1alpha1.ComponentSource {
+	for i := range spec.ComponentSources {
+		if spec.ComponentSources[i].Name == name {
+			return &spec.ComponentSources[i]
+		}
+	}
+	return nil
+}
+
+// componentMode returns how the named Component is cloned: the mode it is listed with in ComponentSources,
+// Source if it is listed without one, and the default mode of the spec otherwise.
+func componentMode(spec *appstudioredhatcomv1alpha1.ApplicationCloneSpec, name string) appstudioredhatcomv1alpha1.ComponentMode {
+	if source := componentSource(spec, name); source != nil {
+		if source.Mode == "" {
+			return appstudioredhatcomv1alpha1.ComponentModeSource
+		}
+		return source.Mode
+	}
+
+	if spec.DefaultMode == "" {
+		return appstudioredhatcomv1alpha1.ComponentModeImage
+	}
+	return spec.DefaultMode
+}
+
+// buildAnnotations returns the build service annotations of a Component cloned from source. The settings are
+// given from the broadest to the narrowest, the narrower ones taking precedence; nil settings are ignored.
+// Images are private and no initial build is triggered unless set otherwise. The annotations for the visibility
+// and the initial build take precedence over the extra ones.
+func buildAnnotations(settings ...*appstudioredhatcomv1alpha1.BuildSettings) map[string]string {
+	annotations := map[string]string{}
+	visibility := appstudioredhatcomv1alpha1.ImageVisibilityPrivate
+	initialBuild := false
+	for _, s := range settings {
+		if s == nil {
+			continue
+		}
+		if s.ImageVisibility != "" {
+			visibility = s.ImageVisibility
+		}
+		if s.InitialBuild != nil {
+			initialBuild = *s.InitialBuild
+		}
+		for k, v := range s.Annotations {
+			annotations[k] = v
+		}
+	}
+
+	annotations[ImageGenerateAnnotation] = fmt.Sprintf(`{"visibility": "%s"}`, strings.ToLower(string(visibility)))
+	annotations[SkipInitialChecksAnnotation] = strconv.FormatBool(!initialBuild)
+	return annotations
+}
+
+// The fields of the upstream ComponentSpec are each classified in one of the lists below. The spec of a cloned
+// Component is a deep copy of the source spec, so that fields added by newer application-api releases are kept;
+// classifying them is enforced by a test.
+var (
+	// copiedComponentFields are cloned as they are
+	copiedComponentFields = []string{"ComponentName", "Secret", "Resources", "Replicas", "TargetPort", "Env", "SkipGitOpsResourceGeneration"}
+
+	// rewrittenComponentFields are set by the clone: the Application the Component belongs to, and the source
+	// code or the image it is built from depending on its mode
+	rewrittenComponentFields = []string{"Application", "Source", "ContainerImage"}
+
+	// strippedComponentFields are left out of the clone. Route is the host name the source Component is exposed
+	// at, which the clone can't share.
+	strippedComponentFields = []string{"Route"}
+)
+
+// cloneComponentSpec returns a copy of the spec of the Component for the cloned Application, without the
+// stripped fields.
+func cloneComponentSpec(c *hasApplicationAPI.Component, application string) hasApplicationAPI.ComponentSpec {
+	spec := c.Spec.DeepCopy()
+	v := reflect.ValueOf(spec).Elem()
+	for _, name := range strippedComponentFields {
+		field := v.FieldByName(name)
+		field.Set(reflect.Zero(field.Type()))
+	}
+	spec.Application = application
+	return *spec
+}
+
+// cloneSourceComponent returns a copy of the Component that is built from its source code in the clone, with
+// the given build service annotations.
+func cloneSourceComponent(c *hasApplicationAPI.Component, namespace, application string, annotations map[string]string) *hasApplicationAPI.Component {
+	component := &hasApplicationAPI.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        c.Name,
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+		Spec: cloneComponentSpec(c, application),
+	}
+	// the image is built in the clone
+	component.Spec.ContainerImage = ""
+	return component
+}
+
+// cloneImageComponent returns a copy of the Component that deploys the given image in the clone.
+func cloneImageComponent(c *hasApplicationAPI.Component, namespace, application, image string) *hasApplicationAPI.Component {
+	component := &hasApplicationAPI.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      c.Name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				SkipInitialChecksAnnotation: "true",
+			},
+		},
+		Spec: cloneComponentSpec(c, application),
+	}
+	component.Spec.Source = hasApplicationAPI.ComponentSource{}
+	component.Spec.ContainerImage = image
+	return component
+}
+
+// getSnapshot reads the named Snapshot of the Application, or its most recent one when the name is empty. A named
+// Snapshot must belong to the Application.
+func getSnapshot(ctx context.Context, c client.Reader, namespace, application, name string) (*hasApplicationAPI.Snapshot, error) {
+	if name != "" {
+		snapshot := &hasApplicationAPI.Snapshot{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("error reading snapshot %s: %w", name, err)
+		}
+		if snapshot.Spec.Application != application {
+			return nil, fmt.Errorf("snapshot %s belongs to application %s, not %s", name, snapshot.Spec.Application, application)
+		}
+		return snapshot, nil
+	}
+
+	snapshotList := &hasApplicationAPI.SnapshotList{}
+	err := c.List(ctx, snapshotList, &client.ListOptions{Namespace: namespace})
+	if err != nil {
+		return nil, fmt.Errorf("error listing snapshots: %w", err)
+	}
+
+	var latest *hasApplicationAPI.Snapshot
+	for i, snapshot := range snapshotList.Items {
+		if snapshot.Spec.Application != application {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(&snapshot.CreationTimestamp) {
+			latest = &snapshotList.Items[i]
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("error finding a snapshot of application %s", application)
+	}
+	return latest, nil
+}
+
+// snapshotImage returns the image of the named Component in the Snapshot.
+func snapshotImage(snapshot *hasApplicationAPI.Snapshot, component string) (string, error) {
+	for _, c := range snapshot.Spec.Components {
+		if c.Name == component {
+			return c.ContainerImage, nil
+		}
+	}
+	return "", fmt.Errorf("error finding component %s in snapshot %s", component, snapshot.Name)
+}
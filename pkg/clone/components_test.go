@@ -0,0 +1,288 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+func newApplication(name string) *hasApplicationAPI.Application {
+	return &hasApplicationAPI.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo"},
+		Spec:       hasApplicationAPI.ApplicationSpec{DisplayName: name},
+	}
+}
+
+func newComponent(name, image string) *hasApplicationAPI.Component {
+	return &hasApplicationAPI.Component{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo"},
+		Spec: hasApplicationAPI.ComponentSpec{
+			ComponentName:  name,
+			Application:    "appfoo",
+			ContainerImage: image,
+			Source: hasApplicationAPI.ComponentSource{
+				ComponentSourceUnion: hasApplicationAPI.ComponentSourceUnion{
+					GitSource: &hasApplicationAPI.GitSource{URL: "github.com/foo/" + name},
+				},
+			},
+		},
+	}
+}
+
+func newSnapshot(name string, created time.Time, images map[string]string) *hasApplicationAPI.Snapshot {
+	snapshot := &hasApplicationAPI.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo", CreationTimestamp: metav1.NewTime(created)},
+		Spec:       hasApplicationAPI.SnapshotSpec{Application: "appfoo"},
+	}
+	for component, image := range images {
+		snapshot.Spec.Components = append(snapshot.Spec.Components, hasApplicationAPI.SnapshotComponent{Name: component, ContainerImage: image})
+	}
+	return snapshot
+}
+
+var _ = Describe("Component modes", func() {
+
+	var testScheme *runtime.Scheme
+	var planner *Planner
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		now := time.Now()
+		source := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			newApplication("appfoo"),
+			newComponent("c1", "quay.io/foo/c1:latest"),
+			newComponent("c2", "quay.io/foo/c2:latest"),
+			newComponent("c3", "quay.io/foo/c3:latest"),
+			newSnapshot("appfoo-old", now.Add(-time.Hour), map[string]string{"c3": "quay.io/foo/c3@sha256:old"}),
+			newSnapshot("appfoo-new", now, map[string]string{"c3": "quay.io/foo/c3@sha256:new"}),
+		).Build()
+		planner = &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+	})
+
+	planComponents := func(spec *appstudioredhatcomv1alpha1.ApplicationCloneSpec) (map[string]*hasApplicationAPI.Component, error) {
+		spec.From = appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"}
+		plan, err := planner.Plan(context.Background(), "bar", spec)
+		if err != nil {
+			return nil, err
+		}
+		components := map[string]*hasApplicationAPI.Component{}
+		for _, obj := range plan.Objects {
+			if component, ok := obj.(*hasApplicationAPI.Component); ok {
+				components[component.Name] = component
+			}
+		}
+		return components, nil
+	}
+
+	It("Should clone each Component in its mode", func() {
+		components, err := planComponents(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{
+				{Name: "c1", Mode: appstudioredhatcomv1alpha1.ComponentModeSource},
+				{Name: "c2", Mode: appstudioredhatcomv1alpha1.ComponentModeSkip},
+				{Name: "c3", Mode: appstudioredhatcomv1alpha1.ComponentModeSnapshot},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(HaveLen(2))
+		Expect(components["c1"].Spec.Source.GitSource.URL).To(Equal("github.com/foo/c1"))
+		Expect(components["c1"].Spec.ContainerImage).To(BeEmpty())
+		Expect(components["c3"].Spec.Source.GitSource).To(BeNil())
+		Expect(components["c3"].Spec.ContainerImage).To(Equal("quay.io/foo/c3@sha256:new"))
+	})
+
+	It("Should clone the Components that aren't listed in the default mode", func() {
+		components, err := planComponents(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{{Name: "c1"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(HaveLen(3))
+		Expect(components["c1"].Spec.Source.GitSource).NotTo(BeNil())
+		Expect(components["c2"].Spec.ContainerImage).To(Equal("quay.io/foo/c2:latest"))
+		Expect(components["c3"].Spec.ContainerImage).To(Equal("quay.io/foo/c3:latest"))
+
+		components, err = planComponents(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{{Name: "c1", Mode: appstudioredhatcomv1alpha1.ComponentModeImage}},
+			DefaultMode:      appstudioredhatcomv1alpha1.ComponentModeSkip,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components).To(HaveLen(1))
+		Expect(components["c1"].Spec.ContainerImage).To(Equal("quay.io/foo/c1:latest"))
+	})
+
+	It("Should take the images of the Snapshot mode from the named Snapshot", func() {
+		components, err := planComponents(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{{Name: "c3", Mode: appstudioredhatcomv1alpha1.ComponentModeSnapshot}},
+			DefaultMode:      appstudioredhatcomv1alpha1.ComponentModeSkip,
+			Snapshot:         "appfoo-old",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components["c3"].Spec.ContainerImage).To(Equal("quay.io/foo/c3@sha256:old"))
+
+		_, err = planComponents(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			DefaultMode: appstudioredhatcomv1alpha1.ComponentModeSnapshot,
+		})
+		Expect(err).To(MatchError(ContainSubstring("error finding component c1 in snapshot appfoo-new")))
+
+		_, err = planComponents(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			DefaultMode: appstudioredhatcomv1alpha1.ComponentModeSnapshot,
+			Snapshot:    "missing",
+		})
+		Expect(err).To(MatchError(ContainSubstring("error reading snapshot missing")))
+	})
+
+	It("Should annotate the Components built from source with their build settings", func() {
+		initialBuild := true
+		planner.Build = &appstudioredhatcomv1alpha1.BuildSettings{
+			Annotations: map[string]string{"build.appstudio.openshift.io/pipeline": `{"name": "docker-build"}`},
+		}
+		components, err := planComponents(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{
+				{Name: "c1"},
+				{Name: "c2", Build: &appstudioredhatcomv1alpha1.BuildSettings{
+					ImageVisibility: appstudioredhatcomv1alpha1.ImageVisibilityPublic,
+					Annotations:     map[string]string{"build.appstudio.openshift.io/pipeline": `{"name": "fbc-builder"}`},
+				}},
+			},
+			Build: &appstudioredhatcomv1alpha1.BuildSettings{
+				InitialBuild: &initialBuild,
+				Annotations:  map[string]string{"build.appstudio.openshift.io/request": "configure-pac"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(components["c1"].Annotations).To(Equal(map[string]string{
+			ImageGenerateAnnotation:                 `{"visibility": "private"}`,
+			SkipInitialChecksAnnotation:             "false",
+			"build.appstudio.openshift.io/pipeline": `{"name": "docker-build"}`,
+			"build.appstudio.openshift.io/request":  "configure-pac",
+		}))
+		Expect(components["c2"].Annotations).To(Equal(map[string]string{
+			ImageGenerateAnnotation:                 `{"visibility": "public"}`,
+			SkipInitialChecksAnnotation:             "false",
+			"build.appstudio.openshift.io/pipeline": `{"name": "fbc-builder"}`,
+			"build.appstudio.openshift.io/request":  "configure-pac",
+		}))
+		Expect(components["c3"].Annotations).To(Equal(map[string]string{SkipInitialChecksAnnotation: "true"}))
+	})
+
+	It("Should keep images private and skip the initial build by default", func() {
+		Expect(buildAnnotations(nil, nil)).To(Equal(map[string]string{
+			ImageGenerateAnnotation:     `{"visibility": "private"}`,
+			SkipInitialChecksAnnotation: "true",
+		}))
+	})
+
+	It("Should copy the spec of the Components but the stripped fields", func() {
+		replicas := 2
+		c := newComponent("c1", "quay.io/foo/c1:latest")
+		c.Spec.Secret = "git-token"
+		c.Spec.Replicas = &replicas
+		c.Spec.Route = "c1-foo.apps.example.com"
+		c.Spec.SkipGitOpsResourceGeneration = true
+		c.Spec.Source.GitSource.DevfileURL = "https://github.com/foo/c1/devfile.yaml"
+		c.Spec.Source.GitSource.Context = "backend"
+
+		component := cloneSourceComponent(c, "bar", "appbar", nil)
+		Expect(component.Spec).To(Equal(hasApplicationAPI.ComponentSpec{
+			ComponentName: "c1",
+			Application:   "appbar",
+			Secret:        "git-token",
+			Source: hasApplicationAPI.ComponentSource{
+				ComponentSourceUnion: hasApplicationAPI.ComponentSourceUnion{
+					GitSource: &hasApplicationAPI.GitSource{
+						URL:        "github.com/foo/c1",
+						Context:    "backend",
+						DevfileURL: "https://github.com/foo/c1/devfile.yaml",
+					},
+				},
+			},
+			Replicas:                     &replicas,
+			SkipGitOpsResourceGeneration: true,
+		}))
+		Expect(c.Spec.Route).To(Equal("c1-foo.apps.example.com"))
+
+		component = cloneImageComponent(c, "bar", "appbar", "quay.io/foo/c1@sha256:abc")
+		Expect(component.Spec.Source.GitSource).To(BeNil())
+		Expect(component.Spec.ContainerImage).To(Equal("quay.io/foo/c1@sha256:abc"))
+		Expect(component.Spec.Secret).To(Equal("git-token"))
+	})
+
+	It("Should classify every field of the upstream ComponentSpec", func() {
+		classified := map[string]int{}
+		for _, fields := range [][]string{copiedComponentFields, rewrittenComponentFields, strippedComponentFields} {
+			for _, name := range fields {
+				classified[name]++
+			}
+		}
+
+		specType := reflect.TypeOf(hasApplicationAPI.ComponentSpec{})
+		for i := 0; i < specType.NumField(); i++ {
+			name := specType.Field(i).Name
+			Expect(classified).To(HaveKeyWithValue(name, 1), "ComponentSpec.%s must be listed as copied, rewritten or stripped exactly once", name)
+			delete(classified, name)
+		}
+		Expect(classified).To(BeEmpty(), "the classified fields must exist in ComponentSpec")
+	})
+
+	It("Should fail the Snapshot mode when the Application has no Snapshot", func() {
+		snapshot, err := getSnapshot(context.Background(), fake.NewClientBuilder().WithScheme(testScheme).Build(), "foo", "appfoo", "")
+		Expect(err).To(MatchError(ContainSubstring("error finding a snapshot of application appfoo")))
+		Expect(snapshot).To(BeNil())
+	})
+
+	It("Should only take the images of the Snapshot mode from a Snapshot of the Application", func() {
+		other := newSnapshot("appbar-snapshot", time.Now(), map[string]string{"c3": "quay.io/bar/c3@sha256:abc"})
+		other.Spec.Application = "appbar"
+		source := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(other).Build()
+
+		snapshot, err := getSnapshot(context.Background(), source, "foo", "appfoo", "appbar-snapshot")
+		Expect(err).To(MatchError("snapshot appbar-snapshot belongs to application appbar, not appfoo"))
+		Expect(snapshot).To(BeNil())
+	})
+
+	It("Should refuse the Source mode for Components without a Git source", func() {
+		c := newComponent("c1", "quay.io/foo/c1:latest")
+		c.Spec.Source = hasApplicationAPI.ComponentSource{}
+		req := &Request{Planner: planner, Namespace: "bar", Spec: &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:             appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{{Name: "c1"}},
+		}}
+
+		_, err := componentCloner{}.Transform(context.Background(), req, c)
+		Expect(err).To(MatchError("error cloning component c1: it has no Git source to build from, clone it in the Image or Snapshot mode"))
+
+		req.Spec.ComponentSources[0].Mode = appstudioredhatcomv1alpha1.ComponentModeImage
+		component, err := componentCloner{}.Transform(context.Background(), req, c)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(component.(*hasApplicationAPI.Component).Spec.ContainerImage).To(Equal("quay.io/foo/c1:latest"))
+	})
+})
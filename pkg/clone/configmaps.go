@@ -0,0 +1,108 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+)
+
+// envConfigMapCloner clones the ConfigMaps the environment variables of the cloned Components refer to, so that
+// the cloned workloads find them. It isn't registered by kind, as the ConfigMaps it clones aren't selected by
+// their labels.
+type envConfigMapCloner struct {
+	BaseCloner
+}
+
+// Discover reads the ConfigMaps the planned Components refer to. The ones missing from the source namespace are
+// left out, e.g. as bundles don't hold ConfigMaps.
+func (envConfigMapCloner) Discover(ctx context.Context, req *Request) ([]client.Object, error) {
+	log := ctrllog.FromContext(ctx)
+
+	var configMaps []client.Object
+	for _, name := range envConfigMapNames(req.Plan) {
+		configMap := &corev1.ConfigMap{}
+		err := req.Planner.Source.Get(ctx, types.NamespacedName{Namespace: req.Spec.From.Namespace, Name: name}, configMap)
+		if errors.IsNotFound(err) {
+			log.Info("ConfigMap not found, skipping", "configmap", name)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading configmap %s: %w", name, err)
+		}
+		configMaps = append(configMaps, configMap)
+	}
+	return configMaps, nil
+}
+
+// Transform copies the ConfigMap into the target namespace, with the overrides of the spec
+func (envConfigMapCloner) Transform(ctx context.Context, req *Request, source client.Object) (client.Object, error) {
+	configMap := source.(*corev1.ConfigMap).DeepCopy()
+	configMap.ObjectMeta = metav1.ObjectMeta{
+		Name:        configMap.Name,
+		Namespace:   req.Namespace,
+		Labels:      configMap.Labels,
+		Annotations: clonedAnnotations(configMap.Annotations),
+	}
+
+	for _, override := range req.Spec.ConfigMapOverrides {
+		if override.Name != configMap.Name {
+			continue
+		}
+		if configMap.Data == nil && len(override.Data) > 0 {
+			configMap.Data = map[string]string{}
+		}
+		for k, v := range override.Data {
+			configMap.Data[k] = v
+		}
+	}
+	return configMap, nil
+}
+
+// envConfigMapNames returns the names of the ConfigMaps the environment variables of the planned Components
+// refer to, in the order they are first referred to.
+func envConfigMapNames(plan *Plan) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, obj := range plan.Objects {
+		component, ok := obj.(*hasApplicationAPI.Component)
+		if !ok {
+			continue
+		}
+		for _, env := range component.Spec.Env {
+			if env.ValueFrom == nil || env.ValueFrom.ConfigMapKeyRef == nil {
+				continue
+			}
+			name := env.ValueFrom.ConfigMapKeyRef.Name
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
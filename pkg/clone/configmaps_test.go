@@ -0,0 +1,143 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+func configMapEnv(name, configMap, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMap},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+var _ = Describe("ConfigMaps of environment variables", func() {
+
+	var testScheme *runtime.Scheme
+	var source client.Client
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		c1 := newComponent("c1", "quay.io/foo/c1:latest")
+		c1.Spec.Env = []corev1.EnvVar{
+			{Name: "REGION", Value: "eu"},
+			configMapEnv("LOG_LEVEL", "c1-config", "log-level"),
+			configMapEnv("DB_HOST", "shared-config", "db-host"),
+		}
+		c2 := newComponent("c2", "quay.io/foo/c2:latest")
+		c2.Spec.Env = []corev1.EnvVar{
+			configMapEnv("DB_HOST", "shared-config", "db-host"),
+			configMapEnv("FEATURES", "missing-config", "features"),
+		}
+		c3 := newComponent("c3", "quay.io/foo/c3:latest")
+		c3.Spec.Env = []corev1.EnvVar{configMapEnv("THEME", "c3-config", "theme")}
+
+		source = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			newApplication("appfoo"),
+			c1, c2, c3,
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "c1-config", Namespace: "foo", Labels: map[string]string{"team": "billing"}, UID: "1234"},
+				Data:       map[string]string{"log-level": "info"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "shared-config", Namespace: "foo"},
+				Data:       map[string]string{"db-host": "db.foo.svc"},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "c3-config", Namespace: "foo"},
+				Data:       map[string]string{"theme": "dark"},
+			},
+		).Build()
+	})
+
+	It("Should clone the ConfigMaps the cloned Components refer to", func() {
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:             appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{{Name: "c3", Mode: appstudioredhatcomv1alpha1.ComponentModeSkip}},
+			ConfigMapOverrides: []appstudioredhatcomv1alpha1.ConfigMapOverride{
+				{Name: "shared-config", Data: map[string]string{"db-host": "db.bar.svc", "db-port": "5432"}},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Resources()).To(Equal([]appstudioredhatcomv1alpha1.Resource{
+			{Kind: "Application", Name: "appfoo"},
+			{Kind: "Component", Name: "c1"},
+			{Kind: "Component", Name: "c2"},
+			{Kind: "ConfigMap", Name: "c1-config"},
+			{Kind: "ConfigMap", Name: "shared-config"},
+		}))
+
+		configMap := plan.Objects[3].(*corev1.ConfigMap)
+		Expect(configMap.Namespace).To(Equal("bar"))
+		Expect(configMap.UID).To(BeEmpty())
+		Expect(configMap.Labels).To(HaveKeyWithValue("team", "billing"))
+		Expect(configMap.Data).To(Equal(map[string]string{"log-level": "info"}))
+		Expect(plan.Objects[4].(*corev1.ConfigMap).Data).To(Equal(map[string]string{"db-host": "db.bar.svc", "db-port": "5432"}))
+
+		target := fake.NewClientBuilder().WithScheme(testScheme).Build()
+		Expect((&Applier{Client: target}).Apply(context.Background(), plan)).To(Succeed())
+		Expect(target.Get(context.Background(), types.NamespacedName{Namespace: "bar", Name: "shared-config"}, &corev1.ConfigMap{})).To(Succeed())
+	})
+
+	It("Should clone a ConfigMap once when it is an extra resource as well", func() {
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From: appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			ExtraResources: []appstudioredhatcomv1alpha1.ExtraResource{{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Selector:   metav1.LabelSelector{MatchLabels: map[string]string{"team": "billing"}},
+			}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Resources()).To(Equal([]appstudioredhatcomv1alpha1.Resource{
+			{Kind: "Application", Name: "appfoo"},
+			{Kind: "Component", Name: "c1"},
+			{Kind: "Component", Name: "c2"},
+			{Kind: "Component", Name: "c3"},
+			{Kind: "ConfigMap", Name: "c1-config"},
+			{Kind: "ConfigMap", Name: "shared-config"},
+			{Kind: "ConfigMap", Name: "c3-config"},
+		}))
+	})
+})
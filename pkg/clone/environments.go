@@ -0,0 +1,151 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+// discoverEnvironments returns the Environments of the source namespace that the Application uses:
+// the ones referenced by its IntegrationTestScenarios and SnapshotEnvironmentBindings, followed by
+// their parent Environments.
+func discoverEnvironments(ctx context.Context, c client.Reader, namespace string, tests []integrationtestapi.IntegrationTestScenario, bindings []hasApplicationAPI.SnapshotEnvironmentBinding) ([]hasApplicationAPI.Environment, error) {
+	log := ctrllog.FromContext(ctx)
+
+	var names []string
+	for _, integrationTest := range tests {
+		if integrationTest.Spec.Environment.Name != "" {
+			names = append(names, integrationTest.Spec.Environment.Name)
+		}
+	}
+	for _, binding := range bindings {
+		names = append(names, binding.Spec.Environment)
+	}
+
+	var environments []hasApplicationAPI.Environment
+	seen := map[string]bool{}
+
+	for len(names) > 0 {
+		name := names[0]
+		names = names[1:]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		environment := hasApplicationAPI.Environment{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &environment)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.Info("referenced Environment not found, skipping", "namespace", namespace, "environment", name)
+				continue
+			}
+			return nil, fmt.Errorf("error reading environment %s: %w", name, err)
+		}
+		environments = append(environments, environment)
+
+		if environment.Spec.ParentEnvironment != "" {
+			names = append(names, environment.Spec.ParentEnvironment)
+		}
+	}
+
+	return environments, nil
+}
+
+// ValidateEnvironmentCloning checks the names the Environments are cloned or mapped to, as far as they are known
+// before the source Environments are: the mapped names must be DNS-1123 labels, and the prefix and suffix must
+// leave room for the names of the source Environments.
+func ValidateEnvironmentCloning(cloning *appstudioredhatcomv1alpha1.EnvironmentCloning) error {
+	if cloning == nil {
+		return nil
+	}
+	for _, mapping := range cloning.Mappings {
+		if errs := validation.IsDNS1123Label(mapping.To); len(errs) > 0 {
+			return fmt.Errorf("invalid name %q of the environment mapped to %s: %s", mapping.To, mapping.From, strings.Join(errs, ", "))
+		}
+	}
+	if cloning.NamePrefix != "" || cloning.NameSuffix != "" {
+		// the shortest source name must make a valid name
+		if errs := validation.IsDNS1123Label(cloning.NamePrefix + "a" + cloning.NameSuffix); len(errs) > 0 {
+			return fmt.Errorf("invalid environment name prefix %q or suffix %q: %s", cloning.NamePrefix, cloning.NameSuffix, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// environmentNames maps the names of the source Environments to their names in the current namespace,
+// covering both the mapped and the cloned ones. Every name must be a DNS-1123 label.
+func environmentNames(cloning *appstudioredhatcomv1alpha1.EnvironmentCloning, environments []hasApplicationAPI.Environment) (map[string]string, error) {
+	names := map[string]string{}
+	if cloning == nil {
+		return names, nil
+	}
+
+	for _, mapping := range cloning.Mappings {
+		names[mapping.From] = mapping.To
+	}
+
+	if cloning.Clone {
+		for _, environment := range environments {
+			if cloning.IsMapped(environment.Name) {
+				continue
+			}
+			names[environment.Name] = cloning.NamePrefix + environment.Name + cloning.NameSuffix
+		}
+	}
+
+	for from, to := range names {
+		if errs := validation.IsDNS1123Label(to); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid name %q of the clone of environment %s: %s", to, from, strings.Join(errs, ", "))
+		}
+	}
+	return names, nil
+}
+
+// cloneEnvironment returns a copy of the source Environment for the given namespace, with its name and
+// parent rewritten. The deployment target and cluster credentials are specific to the source namespace
+// and are not copied.
+func cloneEnvironment(environment *hasApplicationAPI.Environment, namespace string, names map[string]string) *hasApplicationAPI.Environment {
+	spec := environment.Spec.DeepCopy()
+
+	if parent, ok := names[spec.ParentEnvironment]; ok {
+		spec.ParentEnvironment = parent
+	}
+	spec.Configuration.Target = hasApplicationAPI.EnvironmentTarget{}
+	spec.UnstableConfigurationFields = nil
+
+	return &hasApplicationAPI.Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names[environment.Name],
+			Namespace: namespace,
+		},
+		Spec: *spec,
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+func newEnvironment(name, parent string) *hasApplicationAPI.Environment {
+	return &hasApplicationAPI.Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "foo",
+		},
+		Spec: hasApplicationAPI.EnvironmentSpec{
+			DisplayName:       name,
+			ParentEnvironment: parent,
+			Configuration: hasApplicationAPI.EnvironmentConfiguration{
+				Env: []hasApplicationAPI.EnvVarPair{{Name: "LOG_LEVEL", Value: "debug"}},
+				Target: hasApplicationAPI.EnvironmentTarget{
+					DeploymentTargetClaim: hasApplicationAPI.DeploymentTargetClaimConfig{ClaimName: "claim"},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("Environment cloning", func() {
+
+	It("Should discover the Environments used by the Application and their parents", func() {
+		testScheme := runtime.NewScheme()
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			newEnvironment("development", ""),
+			newEnvironment("staging", "development"),
+			newEnvironment("testing", ""),
+			newEnvironment("unrelated", ""),
+			&hasApplicationAPI.SnapshotEnvironmentBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "appfoo-staging", Namespace: "foo"},
+				Spec: hasApplicationAPI.SnapshotEnvironmentBindingSpec{
+					Application: "appfoo",
+					Environment: "staging",
+				},
+			},
+			&hasApplicationAPI.SnapshotEnvironmentBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: "appbar-unrelated", Namespace: "foo"},
+				Spec: hasApplicationAPI.SnapshotEnvironmentBindingSpec{
+					Application: "appbar",
+					Environment: "unrelated",
+				},
+			},
+		).Build()
+
+		tests := []integrationtestapi.IntegrationTestScenario{
+			newIntegrationTest("e2e", "appfoo", nil),
+			newIntegrationTest("missing", "appfoo", nil),
+		}
+		tests[0].Spec.Environment.Name = "testing"
+		tests[1].Spec.Environment.Name = "does-not-exist"
+
+		bindings, err := listSnapshotEnvironmentBindings(context.Background(), c, "foo", "appfoo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bindings).To(HaveLen(1))
+
+		environments, err := discoverEnvironments(context.Background(), c, "foo", tests, bindings)
+		Expect(err).NotTo(HaveOccurred())
+
+		var names []string
+		for _, e := range environments {
+			names = append(names, e.Name)
+		}
+		Expect(names).To(ConsistOf("testing", "staging", "development"))
+	})
+
+	It("Should rename the cloned Environments and keep the mapped ones", func() {
+		environments := []hasApplicationAPI.Environment{
+			*newEnvironment("development", ""),
+			*newEnvironment("staging", "development"),
+		}
+		cloning := &appstudioredhatcomv1alpha1.EnvironmentCloning{
+			Clone:      true,
+			NamePrefix: "clone-",
+			Mappings: []appstudioredhatcomv1alpha1.EnvironmentMapping{
+				{From: "development", To: "dev"},
+			},
+		}
+
+		names, err := environmentNames(cloning, environments)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal(map[string]string{
+			"development": "dev",
+			"staging":     "clone-staging",
+		}))
+
+		cloned := cloneEnvironment(&environments[1], "bar", names)
+		Expect(cloned.Name).To(Equal("clone-staging"))
+		Expect(cloned.Namespace).To(Equal("bar"))
+		Expect(cloned.Spec.ParentEnvironment).To(Equal("dev"))
+		Expect(cloned.Spec.Configuration.Env).To(Equal(environments[1].Spec.Configuration.Env))
+		Expect(cloned.Spec.Configuration.Target).To(BeZero())
+	})
+
+	It("Should only map Environments when cloning is disabled", func() {
+		names, err := environmentNames(&appstudioredhatcomv1alpha1.EnvironmentCloning{
+			Mappings: []appstudioredhatcomv1alpha1.EnvironmentMapping{
+				{From: "development", To: "dev"},
+			},
+		}, []hasApplicationAPI.Environment{*newEnvironment("staging", "")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal(map[string]string{"development": "dev"}))
+	})
+
+	It("Should reject invalid Environment names", func() {
+		_, err := environmentNames(&appstudioredhatcomv1alpha1.EnvironmentCloning{
+			Clone:      true,
+			NamePrefix: strings.Repeat("x", 60),
+		}, []hasApplicationAPI.Environment{*newEnvironment("staging", "")})
+		Expect(err).To(MatchError(ContainSubstring("of the clone of environment staging: must be no more than 63 characters")))
+
+		Expect(ValidateEnvironmentCloning(&appstudioredhatcomv1alpha1.EnvironmentCloning{Clone: true, NamePrefix: "clone-"})).To(Succeed())
+		Expect(ValidateEnvironmentCloning(&appstudioredhatcomv1alpha1.EnvironmentCloning{Clone: true, NamePrefix: "Clone_"})).
+			To(MatchError(ContainSubstring(`invalid environment name prefix "Clone_"`)))
+		Expect(ValidateEnvironmentCloning(&appstudioredhatcomv1alpha1.EnvironmentCloning{
+			Mappings: []appstudioredhatcomv1alpha1.EnvironmentMapping{{From: "development", To: "dev.env"}},
+		})).To(MatchError(ContainSubstring(`invalid name "dev.env" of the environment mapped to development`)))
+	})
+})
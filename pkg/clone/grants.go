@@ -0,0 +1,116 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+// CheckGrants returns an error unless the CloneGrants of the source namespace allow the objects of the Application
+// of from, the planned objects of a clone or the objects of an export, to be cloned into the namespace, on behalf
+// of the ServiceAccount if it is set. The kinds granted by all the CloneGrants given to the clone add up. valueless
+// tells which Secrets are cloned without their values, e.g. Plan.Placeholder, which need no grant.
+func CheckGrants(ctx context.Context, source client.Reader, namespace, serviceAccount string, from appstudioredhatcomv1alpha1.From, objects []client.Object, valueless func(client.Object) bool) error {
+	grantList := &appstudioredhatcomv1alpha1.CloneGrantList{}
+	err := source.List(ctx, grantList, &client.ListOptions{Namespace: from.Namespace})
+	if err != nil {
+		return fmt.Errorf("error listing clonegrants: %w", err)
+	}
+
+	var grants []appstudioredhatcomv1alpha1.CloneGrant
+	for _, grant := range grantList.Items {
+		granted, err := grantedTo(&grant, namespace, serviceAccount, from.Name)
+		if err != nil {
+			return err
+		}
+		if granted {
+			grants = append(grants, grant)
+		}
+	}
+	if len(grants) == 0 {
+		return fmt.Errorf("no CloneGrant of namespace %s allows cloning application %s into namespace %s", from.Namespace, from.Name, namespace)
+	}
+
+	for _, obj := range objects {
+		gk := obj.GetObjectKind().GroupVersionKind().GroupKind()
+		if !kindGranted(grants, gk, valueless(obj)) {
+			return fmt.Errorf("no CloneGrant of namespace %s allows cloning %s %s into namespace %s", from.Namespace, gk.Kind, obj.GetName(), namespace)
+		}
+	}
+	return nil
+}
+
+// grantedTo tells whether the CloneGrant lets the Application be cloned into the namespace on behalf of the
+// ServiceAccount
+func grantedTo(grant *appstudioredhatcomv1alpha1.CloneGrant, namespace, serviceAccount, application string) (bool, error) {
+	from := false
+	for _, f := range grant.Spec.From {
+		if f.Namespace == namespace && (f.ServiceAccount == "" || f.ServiceAccount == serviceAccount) {
+			from = true
+			break
+		}
+	}
+	if !from {
+		return false, nil
+	}
+
+	if len(grant.Spec.Applications) == 0 {
+		return true, nil
+	}
+	for _, pattern := range grant.Spec.Applications {
+		matched, err := path.Match(pattern, application)
+		if err != nil {
+			return false, fmt.Errorf("error matching the applications of clonegrant %s: %w", grant.Name, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// kindGranted tells whether one of the CloneGrants allows an object of the kind to be cloned. Applications,
+// Components and the Secrets cloned without their values are always allowed.
+func kindGranted(grants []appstudioredhatcomv1alpha1.CloneGrant, gk schema.GroupKind, valueless bool) bool {
+	if gk == hasApplicationAPI.GroupVersion.WithKind("Application").GroupKind() || gk == hasApplicationAPI.GroupVersion.WithKind("Component").GroupKind() {
+		return true
+	}
+	secret := gk == schema.GroupKind{Kind: "Secret"}
+	if secret && valueless {
+		return true
+	}
+
+	for _, grant := range grants {
+		if len(grant.Spec.Kinds) == 0 && !secret {
+			return true
+		}
+		for _, kind := range grant.Spec.Kinds {
+			if kind.Group == gk.Group && kind.Kind == gk.Kind {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+var _ = Describe("Clone grants", func() {
+
+	var testScheme *runtime.Scheme
+	var source client.Client
+	from := appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"}
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+		Expect(appstudioredhatcomv1alpha1.AddToScheme(testScheme)).To(Succeed())
+
+		c1 := newComponent("c1", "quay.io/foo/c1:latest")
+		c1.Spec.Secret = "git-token"
+		source = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			newApplication("appfoo"),
+			c1,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "git-token", Namespace: "foo"},
+				Data:       map[string][]byte{"password": []byte("s3cr3t")},
+			},
+		).Build()
+	})
+
+	plan := func(strategy appstudioredhatcomv1alpha1.SecretStrategy) *Plan {
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{From: from, DefaultSecretStrategy: strategy})
+		Expect(err).NotTo(HaveOccurred())
+		return plan
+	}
+
+	grant := func(name string, spec appstudioredhatcomv1alpha1.CloneGrantSpec) {
+		Expect(source.Create(context.Background(), &appstudioredhatcomv1alpha1.CloneGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "foo"},
+			Spec:       spec,
+		})).To(Succeed())
+	}
+
+	It("Should refuse clones no grant covers", func() {
+		p := plan(appstudioredhatcomv1alpha1.SecretStrategyReference)
+		Expect(CheckGrants(context.Background(), source, "bar", "", from, p.Objects, p.Placeholder)).
+			To(MatchError("no CloneGrant of namespace foo allows cloning application appfoo into namespace bar"))
+
+		grant("other-namespace", appstudioredhatcomv1alpha1.CloneGrantSpec{From: []appstudioredhatcomv1alpha1.CloneGrantFrom{{Namespace: "baz"}}})
+		grant("other-service-account", appstudioredhatcomv1alpha1.CloneGrantSpec{From: []appstudioredhatcomv1alpha1.CloneGrantFrom{{Namespace: "bar", ServiceAccount: "clone-bot"}}})
+		grant("other-applications", appstudioredhatcomv1alpha1.CloneGrantSpec{
+			From:         []appstudioredhatcomv1alpha1.CloneGrantFrom{{Namespace: "bar"}},
+			Applications: []string{"billing-*"},
+		})
+		Expect(CheckGrants(context.Background(), source, "bar", "", from, p.Objects, p.Placeholder)).To(HaveOccurred())
+		Expect(CheckGrants(context.Background(), source, "bar", "deploy-bot", from, p.Objects, p.Placeholder)).To(HaveOccurred())
+		Expect(CheckGrants(context.Background(), source, "bar", "clone-bot", from, p.Objects, p.Placeholder)).To(Succeed())
+
+		grant("appfoo", appstudioredhatcomv1alpha1.CloneGrantSpec{From: []appstudioredhatcomv1alpha1.CloneGrantFrom{{Namespace: "bar"}}})
+		Expect(CheckGrants(context.Background(), source, "bar", "", from, p.Objects, p.Placeholder)).To(Succeed())
+	})
+
+	It("Should allow the granted kinds", func() {
+		grant("appfoo", appstudioredhatcomv1alpha1.CloneGrantSpec{
+			From:         []appstudioredhatcomv1alpha1.CloneGrantFrom{{Namespace: "bar"}},
+			Applications: []string{"app*"},
+		})
+
+		// placeholders hold no values
+		placeholders := plan(appstudioredhatcomv1alpha1.SecretStrategyPlaceholder)
+		Expect(CheckGrants(context.Background(), source, "bar", "", from, placeholders.Objects, placeholders.Placeholder)).To(Succeed())
+
+		copied := plan(appstudioredhatcomv1alpha1.SecretStrategyCopy)
+		Expect(CheckGrants(context.Background(), source, "bar", "", from, copied.Objects, copied.Placeholder)).
+			To(MatchError("no CloneGrant of namespace foo allows cloning Secret git-token into namespace bar"))
+
+		grant("secrets", appstudioredhatcomv1alpha1.CloneGrantSpec{
+			From:  []appstudioredhatcomv1alpha1.CloneGrantFrom{{Namespace: "bar"}},
+			Kinds: []appstudioredhatcomv1alpha1.GrantedKind{{Kind: "Secret"}},
+		})
+		Expect(CheckGrants(context.Background(), source, "bar", "", from, copied.Objects, copied.Placeholder)).To(Succeed())
+	})
+
+	It("Should treat copies of filled-in placeholders as Secrets with values", func() {
+		grant("appfoo", appstudioredhatcomv1alpha1.CloneGrantSpec{From: []appstudioredhatcomv1alpha1.CloneGrantFrom{{Namespace: "bar"}}})
+
+		// the source Secret is a placeholder of an earlier clone whose values were filled in
+		secret := &corev1.Secret{}
+		Expect(source.Get(context.Background(), types.NamespacedName{Namespace: "foo", Name: "git-token"}, secret)).To(Succeed())
+		secret.Annotations = map[string]string{PlaceholderAnnotation: "true"}
+		secret.Labels = map[string]string{PlaceholderLabel: "true"}
+		Expect(source.Update(context.Background(), secret)).To(Succeed())
+
+		copied := plan(appstudioredhatcomv1alpha1.SecretStrategyCopy)
+		Expect(copied.Objects[2].GetName()).To(Equal("git-token"))
+		Expect(copied.Objects[2].GetAnnotations()).NotTo(HaveKey(PlaceholderAnnotation))
+		Expect(copied.Objects[2].GetLabels()).NotTo(HaveKey(PlaceholderLabel))
+		Expect(copied.Objects[2].(*corev1.Secret).Data).To(HaveKeyWithValue("password", []byte("s3cr3t")))
+		Expect(copied.Placeholder(copied.Objects[2])).To(BeFalse())
+		Expect(CheckGrants(context.Background(), source, "bar", "", from, copied.Objects, copied.Placeholder)).
+			To(MatchError("no CloneGrant of namespace foo allows cloning Secret git-token into namespace bar"))
+
+		planner := &Planner{
+			Source:   source,
+			Target:   fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			Scheme:   testScheme,
+			Policies: []appstudioredhatcomv1alpha1.ClonePolicy{{ObjectMeta: metav1.ObjectMeta{Name: "no-copies"}, Spec: appstudioredhatcomv1alpha1.ClonePolicySpec{AllowedSecretStrategies: []appstudioredhatcomv1alpha1.SecretStrategy{appstudioredhatcomv1alpha1.SecretStrategyPlaceholder}}}},
+		}
+		_, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{From: from, DefaultSecretStrategy: appstudioredhatcomv1alpha1.SecretStrategyCopy})
+		Expect(err).To(MatchError(ContainSubstring("Secret git-token can't be copied")))
+	})
+})
@@ -0,0 +1,236 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+// integrationTestCloner clones the selected IntegrationTestScenarios of the Application, re-pointed to the
+// cloned or overridden Environments
+type integrationTestCloner struct {
+	BaseCloner
+}
+
+// Discover lists the IntegrationTestScenarios of the source Application that pass the selection
+func (integrationTestCloner) Discover(ctx context.Context, req *Request) ([]client.Object, error) {
+	testsList := &integrationtestapi.IntegrationTestScenarioList{}
+	err := req.Planner.Source.List(ctx, testsList, &client.ListOptions{Namespace: req.Spec.From.Namespace})
+	if err != nil {
+		return nil, fmt.Errorf("error listing integrationtestscenarios: %w", err)
+	}
+
+	selectedTests, err := SelectIntegrationTests(req.Spec.IntegrationTests, req.Spec.From.Name, testsList.Items)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting integration tests: %w", err)
+	}
+
+	var tests []client.Object
+	for i := range selectedTests {
+		tests = append(tests, &selectedTests[i])
+	}
+	return tests, nil
+}
+
+// Transform returns the IntegrationTestScenario with its Environment mapped and its overrides applied
+func (integrationTestCloner) Transform(ctx context.Context, req *Request, source client.Object) (client.Object, error) {
+	integrationTest := source.(*integrationtestapi.IntegrationTestScenario).DeepCopy()
+
+	if name, ok := req.Environments[integrationTest.Spec.Environment.Name]; ok {
+		integrationTest.Spec.Environment.Name = name
+	}
+
+	environment, err := overrideIntegrationTest(req.Spec.IntegrationTestOverrides, integrationTest)
+	if err != nil {
+		return nil, fmt.Errorf("error overriding integration test %s: %w", integrationTest.Name, err)
+	}
+
+	if environment != "" {
+		targetEnvironment := &hasApplicationAPI.Environment{}
+		err = req.Planner.getTarget(ctx, req.Plan, types.NamespacedName{Namespace: req.Namespace, Name: environment}, targetEnvironment)
+		if err != nil {
+			return nil, fmt.Errorf("error reading environment %s for integration test %s: %w", environment, integrationTest.Name, err)
+		}
+		integrationTest.Spec.Environment.Name = targetEnvironment.Name
+		if targetEnvironment.Spec.Type != "" {
+			integrationTest.Spec.Environment.Type = targetEnvironment.Spec.Type
+		}
+	}
+
+	return &integrationtestapi.IntegrationTestScenario{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      integrationTest.Name,
+			Namespace: req.Namespace,
+		},
+		Spec: integrationtestapi.IntegrationTestScenarioSpec{
+			Application: integrationTest.Spec.Application,
+			ResolverRef: integrationTest.Spec.ResolverRef,
+			Params:      integrationTest.Spec.Params,
+			Environment: integrationTest.Spec.Environment,
+			Contexts:    integrationTest.Spec.Contexts,
+		},
+	}, nil
+}
+
+// SelectIntegrationTests returns the IntegrationTestScenarios of the given Application that
+// pass the ApplicationClone's integration test selection.
+func SelectIntegrationTests(selection *appstudioredhatcomv1alpha1.IntegrationTestSelection, application string, tests []integrationtestapi.IntegrationTestScenario) ([]integrationtestapi.IntegrationTestScenario, error) {
+	var selected []integrationtestapi.IntegrationTestScenario
+
+	if selection != nil && selection.None {
+		return selected, nil
+	}
+
+	selector := labels.Everything()
+	if selection != nil && selection.Selector != nil {
+		var err error
+		selector, err = metav1.LabelSelectorAsSelector(selection.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integration test selector: %w", err)
+		}
+	}
+
+	for _, integrationTest := range tests {
+		if integrationTest.Spec.Application != application {
+			continue
+		}
+		if selection != nil {
+			matched, err := integrationTestNameMatches(selection, integrationTest.Name)
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+			if !selector.Matches(labels.Set(integrationTest.Labels)) {
+				continue
+			}
+			if !integrationTestContextMatches(selection.Contexts, integrationTest.Spec.Contexts) {
+				continue
+			}
+		}
+		selected = append(selected, integrationTest)
+	}
+
+	return selected, nil
+}
+
+// integrationTestNameMatches checks the scenario name against the Include and Exclude globs.
+func integrationTestNameMatches(selection *appstudioredhatcomv1alpha1.IntegrationTestSelection, name string) (bool, error) {
+	for _, pattern := range selection.Exclude {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid integration test exclude pattern %q: %w", pattern, err)
+		}
+		if matched {
+			return false, nil
+		}
+	}
+
+	if len(selection.Include) == 0 {
+		return true, nil
+	}
+
+	for _, pattern := range selection.Include {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid integration test include pattern %q: %w", pattern, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// integrationTestContextMatches reports whether the scenario declares any of the wanted contexts.
+func integrationTestContextMatches(wanted []string, contexts []integrationtestapi.TestContext) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+
+	for _, c := range contexts {
+		for _, w := range wanted {
+			if c.Name == w {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// overrideIntegrationTest applies the overrides matching the scenario's name to its spec. It returns
+// the name of the target Environment the scenario has to be re-pointed to, if any.
+func overrideIntegrationTest(overrides []appstudioredhatcomv1alpha1.IntegrationTestOverride, integrationTest *integrationtestapi.IntegrationTestScenario) (string, error) {
+	environment := ""
+
+	for _, override := range overrides {
+		matched, err := path.Match(override.Name, integrationTest.Name)
+		if err != nil {
+			return "", fmt.Errorf("invalid integration test override pattern %q: %w", override.Name, err)
+		}
+		if !matched {
+			continue
+		}
+
+		for _, p := range override.ResolverParams {
+			integrationTest.Spec.ResolverRef.Params = setResolverParam(integrationTest.Spec.ResolverRef.Params, p)
+		}
+		for _, p := range override.Params {
+			integrationTest.Spec.Params = setPipelineParam(integrationTest.Spec.Params, p)
+		}
+		if override.Environment != "" {
+			environment = override.Environment
+		}
+	}
+
+	return environment, nil
+}
+
+func setResolverParam(params []integrationtestapi.ResolverParameter, override appstudioredhatcomv1alpha1.ResolverParam) []integrationtestapi.ResolverParameter {
+	for i := range params {
+		if params[i].Name == override.Name {
+			params[i].Value = override.Value
+			return params
+		}
+	}
+	return append(params, integrationtestapi.ResolverParameter{Name: override.Name, Value: override.Value})
+}
+
+func setPipelineParam(params []integrationtestapi.PipelineParameter, override appstudioredhatcomv1alpha1.PipelineParam) []integrationtestapi.PipelineParameter {
+	for i := range params {
+		if params[i].Name == override.Name {
+			params[i].Value = override.Value
+			params[i].Values = override.Values
+			return params
+		}
+	}
+	return append(params, integrationtestapi.PipelineParameter{Name: override.Name, Value: override.Value, Values: override.Values})
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+func newIntegrationTest(name, application string, labels map[string]string, contexts ...string) integrationtestapi.IntegrationTestScenario {
+	test := integrationtestapi.IntegrationTestScenario{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: integrationtestapi.IntegrationTestScenarioSpec{
+			Application: application,
+		},
+	}
+	for _, c := range contexts {
+		test.Spec.Contexts = append(test.Spec.Contexts, integrationtestapi.TestContext{Name: c})
+	}
+	return test
+}
+
+func integrationTestNames(tests []integrationtestapi.IntegrationTestScenario) []string {
+	var names []string
+	for _, t := range tests {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+var _ = Describe("IntegrationTestScenario selection", func() {
+
+	tests := []integrationtestapi.IntegrationTestScenario{
+		newIntegrationTest("e2e-smoke", "appfoo", map[string]string{"speed": "fast"}, "application"),
+		newIntegrationTest("e2e-full", "appfoo", map[string]string{"speed": "slow"}, "application"),
+		newIntegrationTest("prod-canary", "appfoo", nil, "production"),
+		newIntegrationTest("other-app", "appbar", nil),
+	}
+
+	It("Should select every scenario of the source Application when unset", func() {
+		selected, err := SelectIntegrationTests(nil, "appfoo", tests)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(integrationTestNames(selected)).To(ConsistOf("e2e-smoke", "e2e-full", "prod-canary"))
+	})
+
+	It("Should select nothing when None is set", func() {
+		selected, err := SelectIntegrationTests(&appstudioredhatcomv1alpha1.IntegrationTestSelection{
+			None:    true,
+			Include: []string{"*"},
+		}, "appfoo", tests)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(selected).To(BeEmpty())
+	})
+
+	It("Should apply the include and exclude globs", func() {
+		selected, err := SelectIntegrationTests(&appstudioredhatcomv1alpha1.IntegrationTestSelection{
+			Include: []string{"e2e-*"},
+			Exclude: []string{"*-full"},
+		}, "appfoo", tests)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(integrationTestNames(selected)).To(ConsistOf("e2e-smoke"))
+	})
+
+	It("Should apply the label selector", func() {
+		selected, err := SelectIntegrationTests(&appstudioredhatcomv1alpha1.IntegrationTestSelection{
+			Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "speed", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"slow"}},
+				},
+			},
+		}, "appfoo", tests)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(integrationTestNames(selected)).To(ConsistOf("e2e-smoke", "prod-canary"))
+	})
+
+	It("Should filter by context", func() {
+		selected, err := SelectIntegrationTests(&appstudioredhatcomv1alpha1.IntegrationTestSelection{
+			Contexts: []string{"application"},
+		}, "appfoo", tests)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(integrationTestNames(selected)).To(ConsistOf("e2e-smoke", "e2e-full"))
+	})
+
+	It("Should reject malformed patterns", func() {
+		_, err := SelectIntegrationTests(&appstudioredhatcomv1alpha1.IntegrationTestSelection{
+			Include: []string{"["},
+		}, "appfoo", tests)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("IntegrationTestScenario overrides", func() {
+
+	newScenario := func(name string) *integrationtestapi.IntegrationTestScenario {
+		return &integrationtestapi.IntegrationTestScenario{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: integrationtestapi.IntegrationTestScenarioSpec{
+				Application: "appfoo",
+				ResolverRef: integrationtestapi.ResolverRef{
+					Resolver: "git",
+					Params: []integrationtestapi.ResolverParameter{
+						{Name: "url", Value: "https://github.com/foo/tests"},
+						{Name: "revision", Value: "main"},
+					},
+				},
+				Params: []integrationtestapi.PipelineParameter{
+					{Name: "target", Value: "source-ns"},
+				},
+			},
+		}
+	}
+
+	It("Should replace and add parameters of matching scenarios", func() {
+		scenario := newScenario("e2e-smoke")
+		environment, err := overrideIntegrationTest([]appstudioredhatcomv1alpha1.IntegrationTestOverride{
+			{
+				Name:           "e2e-*",
+				ResolverParams: []appstudioredhatcomv1alpha1.ResolverParam{{Name: "revision", Value: "feature-x"}},
+				Params: []appstudioredhatcomv1alpha1.PipelineParam{
+					{Name: "target", Value: "target-ns"},
+					{Name: "tags", Values: []string{"smoke"}},
+				},
+			},
+			{
+				Name:        "*",
+				Environment: "test-env",
+			},
+		}, scenario)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(environment).To(Equal("test-env"))
+		Expect(scenario.Spec.ResolverRef.Params).To(Equal([]integrationtestapi.ResolverParameter{
+			{Name: "url", Value: "https://github.com/foo/tests"},
+			{Name: "revision", Value: "feature-x"},
+		}))
+		Expect(scenario.Spec.Params).To(Equal([]integrationtestapi.PipelineParameter{
+			{Name: "target", Value: "target-ns"},
+			{Name: "tags", Values: []string{"smoke"}},
+		}))
+	})
+
+	It("Should leave other scenarios untouched", func() {
+		scenario := newScenario("prod-canary")
+		environment, err := overrideIntegrationTest([]appstudioredhatcomv1alpha1.IntegrationTestOverride{
+			{
+				Name:           "e2e-*",
+				ResolverParams: []appstudioredhatcomv1alpha1.ResolverParam{{Name: "revision", Value: "feature-x"}},
+				Environment:    "test-env",
+			},
+		}, scenario)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(environment).To(BeEmpty())
+		Expect(scenario).To(Equal(newScenario("prod-canary")))
+	})
+})
@@ -0,0 +1,248 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+// PolicyError is returned when an ApplicationClone or an ApplicationExport violates ClonePolicies of the cluster
+type PolicyError struct {
+	// Kind of the resource violating the policies
+	Kind       string
+	Violations []appstudioredhatcomv1alpha1.PolicyViolation
+}
+
+func (e *PolicyError) Error() string {
+	var messages []string
+	for _, v := range e.Violations {
+		messages = append(messages, v.Policy+": "+v.Message)
+	}
+	return "the " + e.Kind + " violates the ClonePolicies of the cluster: " + strings.Join(messages, "; ")
+}
+
+// policyError returns a PolicyError of the kind holding the violations, or nil if there are none
+func policyError(kind string, violations []appstudioredhatcomv1alpha1.PolicyViolation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &PolicyError{Kind: kind, Violations: violations}
+}
+
+// CheckPolicies returns a PolicyError if the spec of an ApplicationClone breaks rules of the policies. The rules
+// that depend on what is cloned, such as the number of Components, are checked by Plan once Planner.Policies is
+// set.
+func CheckPolicies(policies []appstudioredhatcomv1alpha1.ClonePolicy, spec *appstudioredhatcomv1alpha1.ApplicationCloneSpec) error {
+	var violations []appstudioredhatcomv1alpha1.PolicyViolation
+	violate := func(policy string, format string, a ...interface{}) {
+		violations = append(violations, appstudioredhatcomv1alpha1.PolicyViolation{Policy: policy, Message: fmt.Sprintf(format, a...)})
+	}
+
+	for _, policy := range policies {
+		rules := policy.Spec
+
+		if spec.From.Bundle == nil {
+			blocked, err := blockedSourceNamespace(policy, spec.From.Namespace)
+			if err != nil {
+				return err
+			}
+			if blocked {
+				violate(policy.Name, "Applications can't be cloned from namespace %s", spec.From.Namespace)
+			}
+		}
+
+		if len(rules.AllowedSecretStrategies) > 0 {
+			for _, secret := range spec.Secrets {
+				if !secretStrategyAllowed(rules, secret.Strategy) {
+					violate(policy.Name, "Secret %s can't be cloned in the %s strategy", secret.Name, secret.Strategy)
+				}
+			}
+			if spec.DefaultSecretStrategy != "" && !secretStrategyAllowed(rules, spec.DefaultSecretStrategy) {
+				violate(policy.Name, "Secrets can't be cloned in the %s strategy by default", spec.DefaultSecretStrategy)
+			}
+		}
+
+		if rules.ImageVisibility != "" {
+			if spec.Build != nil && spec.Build.ImageVisibility != "" && spec.Build.ImageVisibility != rules.ImageVisibility {
+				violate(policy.Name, "images must be %s", rules.ImageVisibility)
+			}
+			for _, source := range spec.ComponentSources {
+				if source.Build != nil && source.Build.ImageVisibility != "" && source.Build.ImageVisibility != rules.ImageVisibility {
+					violate(policy.Name, "the images of Component %s must be %s", source.Name, rules.ImageVisibility)
+				}
+			}
+		}
+
+		if rules.RequireImageDigests && spec.From.Bundle != nil && spec.From.Bundle.OCI != nil && !pinned(spec.From.Bundle.OCI.Reference) {
+			violate(policy.Name, "bundle %s must be pinned by digest", spec.From.Bundle.OCI.Reference)
+		}
+	}
+	return policyError("ApplicationClone", violations)
+}
+
+// CheckBundlePolicies returns a PolicyError if the policies block the namespace the Application of a bundle was
+// exported from. Bundles that don't record it are only checked when they are exported.
+func CheckBundlePolicies(policies []appstudioredhatcomv1alpha1.ClonePolicy, sourceNamespace string) error {
+	if sourceNamespace == "" {
+		return nil
+	}
+
+	var violations []appstudioredhatcomv1alpha1.PolicyViolation
+	for _, policy := range policies {
+		blocked, err := blockedSourceNamespace(policy, sourceNamespace)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			violations = append(violations, appstudioredhatcomv1alpha1.PolicyViolation{
+				Policy:  policy.Name,
+				Message: fmt.Sprintf("Applications exported from namespace %s can't be cloned", sourceNamespace),
+			})
+		}
+	}
+	return policyError("ApplicationClone", violations)
+}
+
+// CheckExportPolicies returns a PolicyError if the spec of an ApplicationExport breaks rules of the policies: the
+// Application must not be in a blocked namespace, and Secret values are only exported when the policies let
+// Secrets be copied.
+func CheckExportPolicies(policies []appstudioredhatcomv1alpha1.ClonePolicy, spec *appstudioredhatcomv1alpha1.ApplicationExportSpec) error {
+	var violations []appstudioredhatcomv1alpha1.PolicyViolation
+	violate := func(policy string, format string, a ...interface{}) {
+		violations = append(violations, appstudioredhatcomv1alpha1.PolicyViolation{Policy: policy, Message: fmt.Sprintf(format, a...)})
+	}
+
+	for _, policy := range policies {
+		blocked, err := blockedSourceNamespace(policy, spec.From.Namespace)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			violate(policy.Name, "Applications can't be exported from namespace %s", spec.From.Namespace)
+		}
+
+		if spec.Secrets == appstudioredhatcomv1alpha1.SecretExportInclude && !secretStrategyAllowed(policy.Spec, appstudioredhatcomv1alpha1.SecretStrategyCopy) {
+			violate(policy.Name, "Secret values can't be exported")
+		}
+	}
+	return policyError("ApplicationExport", violations)
+}
+
+// blockedSourceNamespace tells whether the policy keeps Applications of the namespace from being cloned or exported
+func blockedSourceNamespace(policy appstudioredhatcomv1alpha1.ClonePolicy, namespace string) (bool, error) {
+	for _, pattern := range policy.Spec.BlockedSourceNamespaces {
+		matched, err := path.Match(pattern, namespace)
+		if err != nil {
+			return false, fmt.Errorf("error matching the blocked source namespaces of clonepolicy %s: %w", policy.Name, err)
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkPlanPolicies returns a PolicyError if the planned objects break rules of the policies
+func checkPlanPolicies(policies []appstudioredhatcomv1alpha1.ClonePolicy, plan *Plan) error {
+	var violations []appstudioredhatcomv1alpha1.PolicyViolation
+	violate := func(policy string, format string, a ...interface{}) {
+		violations = append(violations, appstudioredhatcomv1alpha1.PolicyViolation{Policy: policy, Message: fmt.Sprintf(format, a...)})
+	}
+
+	componentKind := hasApplicationAPI.GroupVersion.WithKind("Component").GroupKind()
+	var components int
+	for _, obj := range plan.Objects {
+		if obj.GetObjectKind().GroupVersionKind().GroupKind() == componentKind {
+			components++
+		}
+	}
+
+	for _, policy := range policies {
+		rules := policy.Spec
+
+		if rules.MaxComponents != nil && components > int(*rules.MaxComponents) {
+			violate(policy.Name, "the clone has %d Components, more than the %d allowed", components, *rules.MaxComponents)
+		}
+
+		for _, obj := range plan.Objects {
+			switch obj.GetObjectKind().GroupVersionKind().GroupKind() {
+			case schema.GroupKind{Kind: "Secret"}:
+				// placeholders hold no values
+				if !secretStrategyAllowed(rules, appstudioredhatcomv1alpha1.SecretStrategyCopy) && !plan.Placeholder(obj) {
+					violate(policy.Name, "Secret %s can't be copied", obj.GetName())
+				}
+			case componentKind:
+				if !rules.RequireImageDigests {
+					continue
+				}
+				content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+				if err != nil {
+					return fmt.Errorf("error reading component %s: %w", obj.GetName(), err)
+				}
+				image, _, _ := unstructured.NestedString(content, "spec", "containerImage")
+				if image != "" && !pinned(image) {
+					violate(policy.Name, "the image %s of Component %s must be pinned by digest", image, obj.GetName())
+				}
+			}
+		}
+	}
+	return policyError("ApplicationClone", violations)
+}
+
+// secretStrategyAllowed tells whether the rules let Secrets be cloned in the strategy
+func secretStrategyAllowed(rules appstudioredhatcomv1alpha1.ClonePolicySpec, strategy appstudioredhatcomv1alpha1.SecretStrategy) bool {
+	if len(rules.AllowedSecretStrategies) == 0 {
+		return true
+	}
+	for _, allowed := range rules.AllowedSecretStrategies {
+		if allowed == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+// forcedBuildSettings returns the build settings the policies force, nil if they don't force any. Private images
+// win over public ones when policies disagree.
+func forcedBuildSettings(policies []appstudioredhatcomv1alpha1.ClonePolicy) *appstudioredhatcomv1alpha1.BuildSettings {
+	var visibility appstudioredhatcomv1alpha1.ImageVisibility
+	for _, policy := range policies {
+		if policy.Spec.ImageVisibility != "" && visibility != appstudioredhatcomv1alpha1.ImageVisibilityPrivate {
+			visibility = policy.Spec.ImageVisibility
+		}
+	}
+	if visibility == "" {
+		return nil
+	}
+	return &appstudioredhatcomv1alpha1.BuildSettings{ImageVisibility: visibility}
+}
+
+// pinned tells whether the image reference is pinned by a valid digest
+func pinned(reference string) bool {
+	_, err := name.NewDigest(reference)
+	return err == nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+var _ = Describe("Clone policies", func() {
+
+	var testScheme *runtime.Scheme
+	var source client.Client
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		c1 := newComponent("c1", "quay.io/foo/c1@sha256:"+strings.Repeat("0123456789abcdef", 4))
+		c1.Spec.Secret = "git-token"
+		source = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			newApplication("appfoo"),
+			c1,
+			newComponent("c2", "quay.io/foo/c2:latest"),
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "git-token", Namespace: "foo"},
+				Data:       map[string][]byte{"password": []byte("s3cr3t")},
+			},
+		).Build()
+	})
+
+	policy := func(name string, spec appstudioredhatcomv1alpha1.ClonePolicySpec) appstudioredhatcomv1alpha1.ClonePolicy {
+		return appstudioredhatcomv1alpha1.ClonePolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	}
+
+	It("Should check the spec", func() {
+		policies := []appstudioredhatcomv1alpha1.ClonePolicy{
+			policy("no-prod", appstudioredhatcomv1alpha1.ClonePolicySpec{BlockedSourceNamespaces: []string{"prod-*"}}),
+			policy("no-secrets", appstudioredhatcomv1alpha1.ClonePolicySpec{
+				AllowedSecretStrategies: []appstudioredhatcomv1alpha1.SecretStrategy{appstudioredhatcomv1alpha1.SecretStrategyPlaceholder, appstudioredhatcomv1alpha1.SecretStrategyReference},
+			}),
+			policy("private", appstudioredhatcomv1alpha1.ClonePolicySpec{ImageVisibility: appstudioredhatcomv1alpha1.ImageVisibilityPrivate}),
+		}
+		Expect(CheckPolicies(policies, &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From: appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+		})).To(Succeed())
+
+		err := CheckPolicies(policies, &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:    appstudioredhatcomv1alpha1.From{Namespace: "prod-billing", Name: "appfoo"},
+			Secrets: []appstudioredhatcomv1alpha1.SecretCloning{{Name: "git-token", Strategy: appstudioredhatcomv1alpha1.SecretStrategyCopy}},
+			Build:   &appstudioredhatcomv1alpha1.BuildSettings{ImageVisibility: appstudioredhatcomv1alpha1.ImageVisibilityPublic},
+		})
+		Expect(err).To(MatchError("the ApplicationClone violates the ClonePolicies of the cluster: " +
+			"no-prod: Applications can't be cloned from namespace prod-billing; " +
+			"no-secrets: Secret git-token can't be cloned in the Copy strategy; " +
+			"private: images must be Private"))
+		Expect(err.(*PolicyError).Violations).To(HaveLen(3))
+
+		Expect(CheckPolicies(
+			[]appstudioredhatcomv1alpha1.ClonePolicy{policy("digests", appstudioredhatcomv1alpha1.ClonePolicySpec{RequireImageDigests: true})},
+			&appstudioredhatcomv1alpha1.ApplicationCloneSpec{From: appstudioredhatcomv1alpha1.From{
+				Bundle: &appstudioredhatcomv1alpha1.BundleSource{OCI: &appstudioredhatcomv1alpha1.OCIArtifact{Reference: "quay.io/org/templates/billing-app:v1"}},
+			}},
+		)).To(MatchError(ContainSubstring("digests: bundle quay.io/org/templates/billing-app:v1 must be pinned by digest")))
+	})
+
+	It("Should check the spec of exports", func() {
+		policies := []appstudioredhatcomv1alpha1.ClonePolicy{
+			policy("no-prod", appstudioredhatcomv1alpha1.ClonePolicySpec{BlockedSourceNamespaces: []string{"prod-*"}}),
+			policy("no-secrets", appstudioredhatcomv1alpha1.ClonePolicySpec{
+				AllowedSecretStrategies: []appstudioredhatcomv1alpha1.SecretStrategy{appstudioredhatcomv1alpha1.SecretStrategyPlaceholder},
+			}),
+		}
+		Expect(CheckExportPolicies(policies, &appstudioredhatcomv1alpha1.ApplicationExportSpec{
+			From:    appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			Secrets: appstudioredhatcomv1alpha1.SecretExportRedacted,
+		})).To(Succeed())
+
+		Expect(CheckExportPolicies(policies, &appstudioredhatcomv1alpha1.ApplicationExportSpec{
+			From:    appstudioredhatcomv1alpha1.From{Namespace: "prod-billing", Name: "appfoo"},
+			Secrets: appstudioredhatcomv1alpha1.SecretExportInclude,
+		})).To(MatchError("the ApplicationExport violates the ClonePolicies of the cluster: " +
+			"no-prod: Applications can't be exported from namespace prod-billing; " +
+			"no-secrets: Secret values can't be exported"))
+	})
+
+	It("Should check the namespace bundles were exported from", func() {
+		policies := []appstudioredhatcomv1alpha1.ClonePolicy{
+			policy("no-prod", appstudioredhatcomv1alpha1.ClonePolicySpec{BlockedSourceNamespaces: []string{"prod-*"}}),
+		}
+		Expect(CheckBundlePolicies(policies, "foo")).To(Succeed())
+		Expect(CheckBundlePolicies(policies, "")).To(Succeed())
+		Expect(CheckBundlePolicies(policies, "prod-billing")).To(MatchError("the ApplicationClone violates the ClonePolicies of the cluster: " +
+			"no-prod: Applications exported from namespace prod-billing can't be cloned"))
+	})
+
+	It("Should only take valid digests as pinned", func() {
+		digest := "sha256:" + strings.Repeat("0123456789abcdef", 4)
+		Expect(pinned("quay.io/foo/c1@" + digest)).To(BeTrue())
+		Expect(pinned("quay.io/foo/c1:v1@" + digest)).To(BeTrue())
+		Expect(pinned("quay.io/foo/c1:v1")).To(BeFalse())
+		Expect(pinned("quay.io/foo/c1@sha256:0123")).To(BeFalse())
+		Expect(pinned("quay.io/foo/c1@sha256:")).To(BeFalse())
+		Expect(pinned("@" + digest)).To(BeFalse())
+	})
+
+	It("Should check the planned objects", func() {
+		maxComponents := int32(1)
+		planner := &Planner{
+			Source: source,
+			Target: fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			Scheme: testScheme,
+			Policies: []appstudioredhatcomv1alpha1.ClonePolicy{
+				policy("small", appstudioredhatcomv1alpha1.ClonePolicySpec{MaxComponents: &maxComponents}),
+				policy("guardrails", appstudioredhatcomv1alpha1.ClonePolicySpec{
+					AllowedSecretStrategies: []appstudioredhatcomv1alpha1.SecretStrategy{appstudioredhatcomv1alpha1.SecretStrategyPlaceholder},
+					RequireImageDigests:     true,
+				}),
+			},
+		}
+		spec := &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:                  appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			DefaultSecretStrategy: appstudioredhatcomv1alpha1.SecretStrategyCopy,
+		}
+		_, err := planner.Plan(context.Background(), "bar", spec)
+		Expect(err).To(HaveOccurred())
+		Expect(err.(*PolicyError).Violations).To(Equal([]appstudioredhatcomv1alpha1.PolicyViolation{
+			{Policy: "small", Message: "the clone has 2 Components, more than the 1 allowed"},
+			{Policy: "guardrails", Message: "the image quay.io/foo/c2:latest of Component c2 must be pinned by digest"},
+			{Policy: "guardrails", Message: "Secret git-token can't be copied"},
+		}))
+
+		// placeholders hold no values
+		spec.DefaultSecretStrategy = appstudioredhatcomv1alpha1.SecretStrategyPlaceholder
+		spec.ComponentSources = []appstudioredhatcomv1alpha1.ComponentSource{{Name: "c2", Mode: appstudioredhatcomv1alpha1.ComponentModeSkip}}
+		_, err = planner.Plan(context.Background(), "bar", spec)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Should force the image visibility", func() {
+		planner := &Planner{
+			Source:   source,
+			Target:   fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			Scheme:   testScheme,
+			Build:    &appstudioredhatcomv1alpha1.BuildSettings{ImageVisibility: appstudioredhatcomv1alpha1.ImageVisibilityPublic},
+			Policies: []appstudioredhatcomv1alpha1.ClonePolicy{policy("private", appstudioredhatcomv1alpha1.ClonePolicySpec{ImageVisibility: appstudioredhatcomv1alpha1.ImageVisibilityPrivate})},
+		}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:        appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			DefaultMode: appstudioredhatcomv1alpha1.ComponentModeSource,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Objects[1].GetAnnotations()).To(HaveKeyWithValue(ImageGenerateAnnotation, `{"visibility": "private"}`))
+	})
+})
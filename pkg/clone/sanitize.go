@@ -0,0 +1,115 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+)
+
+// sanitizationRule is a SanitizationRule with its regular expressions compiled
+type sanitizationRule struct {
+	appstudioredhatcomv1alpha1.SanitizationRule
+	name, value *regexp.Regexp
+}
+
+// compileSanitizationRules compiles the rules, in order
+func compileSanitizationRules(rules []appstudioredhatcomv1alpha1.SanitizationRule) ([]sanitizationRule, error) {
+	var compiled []sanitizationRule
+	for i, rule := range rules {
+		r := sanitizationRule{SanitizationRule: rule}
+		if rule.Name == "" && rule.Value == "" {
+			return nil, fmt.Errorf("error in sanitization rule %d: at least one of name and value must be set", i)
+		}
+		switch rule.Action {
+		case appstudioredhatcomv1alpha1.SanitizationActionReplace, appstudioredhatcomv1alpha1.SanitizationActionDrop, appstudioredhatcomv1alpha1.SanitizationActionFlag:
+		default:
+			return nil, fmt.Errorf("error in sanitization rule %d: unknown action %q", i, rule.Action)
+		}
+
+		var err error
+		if rule.Name != "" {
+			if r.name, err = regexp.Compile("^(?:" + rule.Name + ")$"); err != nil {
+				return nil, fmt.Errorf("error parsing the name of sanitization rule %d: %w", i, err)
+			}
+		}
+		if rule.Value != "" {
+			if r.value, err = regexp.Compile(rule.Value); err != nil {
+				return nil, fmt.Errorf("error parsing the value of sanitization rule %d: %w", i, err)
+			}
+		}
+		compiled = append(compiled, r)
+	}
+	return compiled, nil
+}
+
+// ValidateSanitizationRules reports the first invalid rule, e.g. of a cluster-wide sanitization policy
+func ValidateSanitizationRules(rules []appstudioredhatcomv1alpha1.SanitizationRule) error {
+	_, err := compileSanitizationRules(rules)
+	return err
+}
+
+// matches tells whether the rule matches the environment variable
+func (r *sanitizationRule) matches(env corev1.EnvVar) bool {
+	if r.name != nil && !r.name.MatchString(env.Name) {
+		return false
+	}
+	if r.value != nil && (env.ValueFrom != nil || !r.value.MatchString(env.Value)) {
+		return false
+	}
+	return true
+}
+
+// sanitize applies the first rule matching each environment variable of the Component to it, and returns the
+// variables that were matched.
+func sanitize(component *hasApplicationAPI.Component, rules []sanitizationRule) []appstudioredhatcomv1alpha1.SanitizedField {
+	var sanitized []appstudioredhatcomv1alpha1.SanitizedField
+	var env []corev1.EnvVar
+	for _, e := range component.Spec.Env {
+		var rule *sanitizationRule
+		for i := range rules {
+			if rules[i].matches(e) {
+				rule = &rules[i]
+				break
+			}
+		}
+		if rule == nil {
+			env = append(env, e)
+			continue
+		}
+
+		sanitized = append(sanitized, appstudioredhatcomv1alpha1.SanitizedField{Component: component.Name, Env: e.Name, Action: rule.Action})
+		switch rule.Action {
+		case appstudioredhatcomv1alpha1.SanitizationActionReplace:
+			if rule.value != nil {
+				e.Value = rule.value.ReplaceAllString(e.Value, rule.Replacement)
+			} else {
+				e.Value, e.ValueFrom = rule.Replacement, nil
+			}
+			env = append(env, e)
+		case appstudioredhatcomv1alpha1.SanitizationActionFlag:
+			env = append(env, e)
+		}
+	}
+	component.Spec.Env = env
+	return sanitized
+}
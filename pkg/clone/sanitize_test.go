@@ -0,0 +1,116 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+var _ = Describe("Sanitization", func() {
+
+	var testScheme *runtime.Scheme
+	var source client.Client
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		c1 := newComponent("c1", "quay.io/foo/c1:latest")
+		c1.Spec.Env = []corev1.EnvVar{
+			{Name: "DB_HOST", Value: "billing.prod.example.com"},
+			{Name: "API_URL", Value: "https://api.prod.example.com/v1"},
+			{Name: "SUPPORT_EMAIL", Value: "support@example.com"},
+			{Name: "DB_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "db-credentials"},
+				Key:                  "password",
+			}}},
+			{Name: "LOG_LEVEL", Value: "info"},
+		}
+		source = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newApplication("appfoo"), c1).Build()
+	})
+
+	It("Should apply the first matching rule to every env var", func() {
+		planner := &Planner{
+			Source: source,
+			Target: fake.NewClientBuilder().WithScheme(testScheme).Build(),
+			Scheme: testScheme,
+			// the cluster-wide policy
+			Sanitization: []appstudioredhatcomv1alpha1.SanitizationRule{
+				{Name: ".*_EMAIL", Action: appstudioredhatcomv1alpha1.SanitizationActionDrop},
+			},
+		}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From: appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			Sanitization: []appstudioredhatcomv1alpha1.SanitizationRule{
+				// relaxing the cluster-wide policy has no effect
+				{Name: "SUPPORT_EMAIL", Action: appstudioredhatcomv1alpha1.SanitizationActionFlag},
+				{Name: "DB_.*", Value: `(\w+)\.prod\.example\.com`, Action: appstudioredhatcomv1alpha1.SanitizationActionReplace, Replacement: "${1}.staging.example.com"},
+				{Name: "DB_PASSWORD", Action: appstudioredhatcomv1alpha1.SanitizationActionReplace, Replacement: "changeme"},
+				{Value: `prod\.example\.com`, Action: appstudioredhatcomv1alpha1.SanitizationActionFlag},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		component := plan.Objects[1].(*hasApplicationAPI.Component)
+		Expect(component.Spec.Env).To(Equal([]corev1.EnvVar{
+			{Name: "DB_HOST", Value: "billing.staging.example.com"},
+			{Name: "API_URL", Value: "https://api.prod.example.com/v1"},
+			{Name: "DB_PASSWORD", Value: "changeme"},
+			{Name: "LOG_LEVEL", Value: "info"},
+		}))
+		Expect(plan.Sanitized()).To(Equal([]appstudioredhatcomv1alpha1.SanitizedField{
+			{Component: "c1", Env: "DB_HOST", Action: appstudioredhatcomv1alpha1.SanitizationActionReplace},
+			{Component: "c1", Env: "API_URL", Action: appstudioredhatcomv1alpha1.SanitizationActionFlag},
+			{Component: "c1", Env: "SUPPORT_EMAIL", Action: appstudioredhatcomv1alpha1.SanitizationActionDrop},
+			{Component: "c1", Env: "DB_PASSWORD", Action: appstudioredhatcomv1alpha1.SanitizationActionReplace},
+		}))
+	})
+
+	It("Should not clone what dropped env vars refer to", func() {
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme}
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:                  appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			DefaultSecretStrategy: appstudioredhatcomv1alpha1.SecretStrategyPlaceholder,
+			Sanitization:          []appstudioredhatcomv1alpha1.SanitizationRule{{Name: "DB_PASSWORD", Action: appstudioredhatcomv1alpha1.SanitizationActionDrop}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secretNames(plan)).To(BeEmpty())
+	})
+
+	It("Should reject invalid rules", func() {
+		Expect(ValidateSanitizationRules([]appstudioredhatcomv1alpha1.SanitizationRule{{Action: appstudioredhatcomv1alpha1.SanitizationActionDrop}})).
+			To(MatchError(ContainSubstring("at least one of name and value must be set")))
+		Expect(ValidateSanitizationRules([]appstudioredhatcomv1alpha1.SanitizationRule{{Name: "DB_(", Action: appstudioredhatcomv1alpha1.SanitizationActionDrop}})).
+			To(MatchError(ContainSubstring("error parsing the name of sanitization rule 0")))
+		Expect(ValidateSanitizationRules([]appstudioredhatcomv1alpha1.SanitizationRule{{Name: "DB_.*", Action: "Hide"}})).
+			To(MatchError(ContainSubstring(`unknown action "Hide"`)))
+	})
+})
@@ -0,0 +1,224 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+)
+
+const (
+	// PlaceholderAnnotation marks the placeholder Secrets of a clone, whose values the user fills in
+	PlaceholderAnnotation = "clone.appstudio.redhat.com/placeholder"
+
+	// PlaceholderLabel is set on the placeholder Secrets of a clone too, so that the controller only watches them
+	// and not every Secret of the cluster. It stays when the PlaceholderAnnotation is removed.
+	PlaceholderLabel = "clone.appstudio.redhat.com/placeholder"
+)
+
+// secretCloner clones the Secrets the cloned Components refer to, as their Git credentials or in their environment
+// variables, with their strategy. Like envConfigMapCloner, it isn't registered by kind.
+type secretCloner struct {
+	BaseCloner
+}
+
+// Discover reads the Secrets the planned Components refer to, except for the ones in the Reference strategy. The
+// ones missing from the source namespace are left out.
+func (secretCloner) Discover(ctx context.Context, req *Request) ([]client.Object, error) {
+	log := ctrllog.FromContext(ctx)
+
+	var secrets []client.Object
+	for _, name := range secretNames(req.Plan) {
+		if secretStrategy(req.Spec, name) == appstudioredhatcomv1alpha1.SecretStrategyReference {
+			log.Info("Secret is referenced, skipping", "secret", name)
+			continue
+		}
+		secret := &corev1.Secret{}
+		err := req.Planner.Source.Get(ctx, types.NamespacedName{Namespace: req.Spec.From.Namespace, Name: name}, secret)
+		if errors.IsNotFound(err) {
+			log.Info("Secret not found, skipping", "secret", name)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading secret %s: %w", name, err)
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// Transform copies the Secret into the target namespace in its strategy. Secrets redacted by an export can only
+// be cloned as placeholders. The PlaceholderAnnotation and PlaceholderLabel of the source Secret are dropped, so
+// that only the placeholders of the plan carry them.
+func (secretCloner) Transform(ctx context.Context, req *Request, source client.Object) (client.Object, error) {
+	s := source.(*corev1.Secret)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        s.Name,
+			Namespace:   req.Namespace,
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Type: s.Type,
+		Data: map[string][]byte{},
+	}
+	for k, v := range s.Labels {
+		if k != PlaceholderLabel {
+			secret.Labels[k] = v
+		}
+	}
+	for k, v := range clonedAnnotations(s.Annotations) {
+		if k != bundle.RedactedAnnotation && k != PlaceholderAnnotation {
+			secret.Annotations[k] = v
+		}
+	}
+	for k, v := range s.Data {
+		secret.Data[k] = v
+	}
+	for k, v := range s.StringData {
+		secret.Data[k] = []byte(v)
+	}
+	secret = secret.DeepCopy()
+
+	strategy := secretStrategy(req.Spec, s.Name)
+	if strategy == appstudioredhatcomv1alpha1.SecretStrategyPlaceholder || s.Annotations[bundle.RedactedAnnotation] == "true" {
+		for k := range secret.Data {
+			secret.Data[k] = placeholderValue(secret.Type, k)
+		}
+		secret.Annotations[PlaceholderAnnotation] = "true"
+		secret.Labels[PlaceholderLabel] = "true"
+		req.Plan.placeholder(secret.Name)
+	}
+	return secret, nil
+}
+
+// placeholderValue returns the value of the key of a placeholder Secret of the type: an empty value, except for
+// the keys the API server validates the content of.
+func placeholderValue(secretType corev1.SecretType, key string) []byte {
+	switch {
+	case secretType == corev1.SecretTypeDockerConfigJson && key == corev1.DockerConfigJsonKey:
+		return []byte(`{"auths":{}}`)
+	case secretType == corev1.SecretTypeDockercfg && key == corev1.DockerConfigKey:
+		return []byte(`{}`)
+	default:
+		return []byte{}
+	}
+}
+
+// secretStrategy returns how the named Secret is cloned: the strategy it is listed with in Secrets, and the
+// default strategy of the spec otherwise.
+func secretStrategy(spec *appstudioredhatcomv1alpha1.ApplicationCloneSpec, name string) appstudioredhatcomv1alpha1.SecretStrategy {
+	for _, secret := range spec.Secrets {
+		if secret.Name == name {
+			return secret.Strategy
+		}
+	}
+
+	if spec.DefaultSecretStrategy == "" {
+		return appstudioredhatcomv1alpha1.SecretStrategyReference
+	}
+	return spec.DefaultSecretStrategy
+}
+
+// secretNames returns the names of the Secrets the planned Components refer to, see SecretNames
+func secretNames(plan *Plan) []string {
+	var components []*hasApplicationAPI.Component
+	for _, obj := range plan.Objects {
+		if component, ok := obj.(*hasApplicationAPI.Component); ok {
+			components = append(components, component)
+		}
+	}
+	return SecretNames(components...)
+}
+
+// SecretNames returns the names of the Secrets the Components refer to, as their Git credentials or in their
+// environment variables, in the order they are first referred to.
+func SecretNames(components ...*hasApplicationAPI.Component) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, component := range components {
+		add(component.Spec.Secret)
+		for _, env := range component.Spec.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				add(env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	return names
+}
+
+// PendingSecrets returns the names of the placeholder Secrets of the plan whose values aren't all filled in yet
+// in the namespace, or that don't exist there yet.
+func PendingSecrets(ctx context.Context, c client.Reader, plan *Plan) ([]string, error) {
+	var pending []string
+	for _, obj := range plan.Objects {
+		if !plan.Placeholder(obj) {
+			continue
+		}
+
+		secret := &corev1.Secret{}
+		err := c.Get(ctx, client.ObjectKeyFromObject(obj), secret)
+		if errors.IsNotFound(err) {
+			pending = append(pending, obj.GetName())
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading secret %s: %w", obj.GetName(), err)
+		}
+		if isPlaceholder(secret) {
+			pending = append(pending, secret.Name)
+		}
+	}
+	return pending, nil
+}
+
+// isPlaceholder tells whether the Secret is a placeholder still waiting for values: it carries the
+// PlaceholderAnnotation and has a key whose value is the placeholder one. Removing the annotation accepts the
+// values as they are.
+func isPlaceholder(secret *corev1.Secret) bool {
+	if secret.Annotations[PlaceholderAnnotation] != "true" {
+		return false
+	}
+	for k, v := range secret.Data {
+		if string(v) == string(placeholderValue(secret.Type, k)) {
+			return true
+		}
+	}
+	for k, v := range secret.StringData {
+		if v == string(placeholderValue(secret.Type, k)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	"github.com/redhat-appstudio/clone-controller/pkg/bundle"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+var _ = Describe("Secrets", func() {
+
+	var testScheme *runtime.Scheme
+	var source client.Client
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(testScheme)).To(Succeed())
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		c1 := newComponent("c1", "quay.io/foo/c1:latest")
+		c1.Spec.Secret = "git-token"
+		c1.Spec.Env = []corev1.EnvVar{{
+			Name: "DB_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "db-credentials"},
+				Key:                  "password",
+			}},
+		}}
+		c2 := newComponent("c2", "quay.io/foo/c2:latest")
+		c2.Spec.Secret = "pull-secret"
+
+		source = fake.NewClientBuilder().WithScheme(testScheme).WithObjects(
+			newApplication("appfoo"),
+			c1, c2,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "git-token", Namespace: "foo", Labels: map[string]string{"team": "billing"}},
+				Type:       corev1.SecretTypeBasicAuth,
+				Data:       map[string][]byte{"username": []byte("bot"), "password": []byte("s3cr3t")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "foo"},
+				Data:       map[string][]byte{"password": []byte("hunter2")},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pull-secret",
+					Namespace:   "foo",
+					Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: `{"data":{".dockerconfigjson":"eyJhdXRocyI6e319"}}`},
+				},
+				Type: corev1.SecretTypeDockerConfigJson,
+				Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"quay.io":{"auth":"Ym90OnMzY3IzdA=="}}}`)},
+			},
+		).Build()
+	})
+
+	plan := func(spec *appstudioredhatcomv1alpha1.ApplicationCloneSpec) *Plan {
+		spec.From = appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"}
+		planner := &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme, Clone: "appfoo-clone"}
+		plan, err := planner.Plan(context.Background(), "bar", spec)
+		Expect(err).NotTo(HaveOccurred())
+		return plan
+	}
+
+	secrets := func(plan *Plan) map[string]*corev1.Secret {
+		secrets := map[string]*corev1.Secret{}
+		for _, obj := range plan.Objects {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				secrets[secret.Name] = secret
+			}
+		}
+		return secrets
+	}
+
+	It("Should leave the referenced Secrets out by default", func() {
+		Expect(secrets(plan(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{}))).To(BeEmpty())
+	})
+
+	It("Should clone the Secrets in their strategy", func() {
+		cloned := secrets(plan(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			Secrets: []appstudioredhatcomv1alpha1.SecretCloning{
+				{Name: "git-token", Strategy: appstudioredhatcomv1alpha1.SecretStrategyCopy},
+				{Name: "db-credentials", Strategy: appstudioredhatcomv1alpha1.SecretStrategyReference},
+			},
+			DefaultSecretStrategy: appstudioredhatcomv1alpha1.SecretStrategyPlaceholder,
+		}))
+		Expect(cloned).To(HaveLen(2))
+
+		Expect(cloned["git-token"].Namespace).To(Equal("bar"))
+		Expect(cloned["git-token"].Type).To(Equal(corev1.SecretTypeBasicAuth))
+		Expect(cloned["git-token"].Labels).To(HaveKeyWithValue("team", "billing"))
+		Expect(cloned["git-token"].Data).To(HaveKeyWithValue("password", []byte("s3cr3t")))
+		Expect(cloned["git-token"].Annotations).NotTo(HaveKey(PlaceholderAnnotation))
+		Expect(cloned["git-token"].Labels).NotTo(HaveKey(PlaceholderLabel))
+
+		// kubectl's record of the source Secret would hold its values
+		Expect(cloned["pull-secret"].Annotations).To(Equal(map[string]string{PlaceholderAnnotation: "true"}))
+		Expect(cloned["pull-secret"].Labels).To(HaveKeyWithValue(PlaceholderLabel, "true"))
+		Expect(cloned["pull-secret"].Data).To(Equal(map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)}))
+	})
+
+	It("Should only clone redacted Secrets as placeholders", func() {
+		secret := &corev1.Secret{}
+		Expect(source.Get(context.Background(), client.ObjectKey{Namespace: "foo", Name: "db-credentials"}, secret)).To(Succeed())
+		secret.Annotations = map[string]string{bundle.RedactedAnnotation: "true"}
+		secret.Data["password"] = []byte{}
+		Expect(source.Update(context.Background(), secret)).To(Succeed())
+
+		cloned := secrets(plan(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{DefaultSecretStrategy: appstudioredhatcomv1alpha1.SecretStrategyCopy}))
+		Expect(cloned["db-credentials"].Annotations).To(Equal(map[string]string{PlaceholderAnnotation: "true"}))
+		Expect(cloned["db-credentials"].Data).To(Equal(map[string][]byte{"password": {}}))
+	})
+
+	It("Should report the placeholders until their values are filled in", func() {
+		p := plan(&appstudioredhatcomv1alpha1.ApplicationCloneSpec{DefaultSecretStrategy: appstudioredhatcomv1alpha1.SecretStrategyPlaceholder})
+		target := fake.NewClientBuilder().WithScheme(testScheme).Build()
+
+		pending, err := PendingSecrets(context.Background(), target, p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(Equal([]string{"git-token", "db-credentials", "pull-secret"}))
+
+		Expect((&Applier{Client: target}).Apply(context.Background(), p)).To(Succeed())
+		secret := &corev1.Secret{}
+		Expect(target.Get(context.Background(), client.ObjectKey{Namespace: "bar", Name: "git-token"}, secret)).To(Succeed())
+		secret.Data = map[string][]byte{"username": []byte("dev"), "password": []byte("t0k3n")}
+		Expect(target.Update(context.Background(), secret)).To(Succeed())
+		Expect(target.Get(context.Background(), client.ObjectKey{Namespace: "bar", Name: "db-credentials"}, secret)).To(Succeed())
+		delete(secret.Annotations, PlaceholderAnnotation)
+		Expect(target.Update(context.Background(), secret)).To(Succeed())
+
+		pending, err = PendingSecrets(context.Background(), target, p)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pending).To(Equal([]string{"pull-secret"}))
+	})
+})
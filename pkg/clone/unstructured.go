@@ -0,0 +1,141 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// unstructure replaces the planned objects with unstructured ones, see Planner.Unstructured.
+func (p *Planner) unstructure(ctx context.Context, plan *Plan) error {
+	for i, obj := range plan.Objects {
+		u, err := p.unstructuredClone(ctx, obj, plan.sources[i])
+		if err != nil {
+			return err
+		}
+		plan.Objects[i] = u
+	}
+	return nil
+}
+
+// unstructuredClone returns the planned object as an unstructured object. When it is cloned from a source
+// object, the source is read again as an unstructured object, in the version chosen by the RESTMapper, and the
+// changes the clone makes to the source are applied to it. The fields of the source the compiled types don't
+// know about are kept that way, unless they are part of a list the clone changes. Objects whose source isn't
+// found, like the Application, are converted as they are.
+func (p *Planner) unstructuredClone(ctx context.Context, obj, source client.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("error converting %s: %w", obj.GetName(), err)
+	}
+	if source == nil {
+		return &unstructured.Unstructured{Object: content}, nil
+	}
+
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if p.RESTMapper != nil {
+		mapping, err := p.RESTMapper.RESTMapping(gvk.GroupKind())
+		if err != nil {
+			return nil, fmt.Errorf("error discovering the version of %s: %w", gvk.Kind, err)
+		}
+		gvk = mapping.GroupVersionKind
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	err = p.Source.Get(ctx, client.ObjectKeyFromObject(source), u)
+	if errors.IsNotFound(err) {
+		return &unstructured.Unstructured{Object: content}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s %s: %w", gvk.Kind, source.GetName(), err)
+	}
+
+	metadata := content["metadata"]
+	original, err := runtime.DefaultUnstructuredConverter.ToUnstructured(source)
+	if err != nil {
+		return nil, fmt.Errorf("error converting %s: %w", source.GetName(), err)
+	}
+	for _, field := range []string{"apiVersion", "kind", "metadata", "status"} {
+		delete(original, field)
+		delete(content, field)
+	}
+	applyMergePatch(u.Object, mergePatch(original, content))
+
+	// The clone has its own metadata, and no status
+	u.Object["metadata"] = metadata
+	delete(u.Object, "status")
+
+	return u, nil
+}
+
+// mergePatch returns the JSON merge patch (RFC 7386) turning original into modified. Objects are patched field
+// by field, other values, including lists, are replaced.
+func mergePatch(original, modified map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for k, v := range modified {
+		o, ok := original[k]
+		if !ok {
+			patch[k] = v
+			continue
+		}
+		originalObject, isObject := o.(map[string]interface{})
+		modifiedObject, isModifiedObject := v.(map[string]interface{})
+		if isObject && isModifiedObject {
+			if p := mergePatch(originalObject, modifiedObject); len(p) > 0 {
+				patch[k] = p
+			}
+			continue
+		}
+		if !reflect.DeepEqual(o, v) {
+			patch[k] = v
+		}
+	}
+	for k := range original {
+		if _, ok := modified[k]; !ok {
+			patch[k] = nil
+		}
+	}
+	return patch
+}
+
+// applyMergePatch applies the JSON merge patch to obj
+func applyMergePatch(obj, patch map[string]interface{}) {
+	for k, v := range patch {
+		if v == nil {
+			delete(obj, k)
+			continue
+		}
+		if p, ok := v.(map[string]interface{}); ok {
+			o, ok := obj[k].(map[string]interface{})
+			if !ok {
+				o = map[string]interface{}{}
+				obj[k] = o
+			}
+			applyMergePatch(o, p)
+			continue
+		}
+		obj[k] = runtime.DeepCopyJSONValue(v)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clone
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hasApplicationAPI "github.com/redhat-appstudio/application-api/api/v1alpha1"
+	appstudioredhatcomv1alpha1 "github.com/redhat-appstudio/clone-controller/api/v1alpha1"
+	integrationtestapi "github.com/redhat-appstudio/integration-service/api/v1beta1"
+)
+
+// unstructuredReader serves the unstructured objects as the cluster would, with the fields the compiled types
+// lack, and the typed objects from the embedded Reader.
+type unstructuredReader struct {
+	client.Reader
+	objects []*unstructured.Unstructured
+}
+
+func (r *unstructuredReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return r.Reader.Get(ctx, key, obj, opts...)
+	}
+	for _, o := range r.objects {
+		if o.GroupVersionKind() == u.GroupVersionKind() && client.ObjectKeyFromObject(o) == key {
+			o.DeepCopyInto(u)
+			return nil
+		}
+	}
+	return errors.NewNotFound(schema.GroupResource{Group: u.GroupVersionKind().Group, Resource: u.GetKind()}, key.Name)
+}
+
+func newUnstructuredComponent(version string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "appstudio.redhat.com/" + version,
+		"kind":       "Component",
+		"metadata":   map[string]interface{}{"name": "c1", "namespace": "foo", "uid": "1234"},
+		"spec": map[string]interface{}{
+			"componentName":  "c1",
+			"application":    "appfoo",
+			"containerImage": "quay.io/foo/c1:latest",
+			"route":          "c1-foo.apps.example.com",
+			"source": map[string]interface{}{
+				"git": map[string]interface{}{"url": "github.com/foo/c1", "sparse": true},
+			},
+			"buildNudgesRef": []interface{}{"c2"},
+		},
+		"status": map[string]interface{}{"devfile": "schemaVersion: 2.2.0"},
+	}}
+}
+
+var _ = Describe("Unstructured cloning", func() {
+
+	var testScheme *runtime.Scheme
+	var planner *Planner
+
+	BeforeEach(func() {
+		testScheme = runtime.NewScheme()
+		Expect(hasApplicationAPI.AddToScheme(testScheme)).To(Succeed())
+		Expect(integrationtestapi.AddToScheme(testScheme)).To(Succeed())
+
+		component := newComponent("c1", "quay.io/foo/c1:latest")
+		component.Spec.Route = "c1-foo.apps.example.com"
+		source := &unstructuredReader{
+			Reader:  fake.NewClientBuilder().WithScheme(testScheme).WithObjects(newApplication("appfoo"), component).Build(),
+			objects: []*unstructured.Unstructured{newUnstructuredComponent("v1alpha1"), newUnstructuredComponent("v1beta1")},
+		}
+		planner = &Planner{Source: source, Target: fake.NewClientBuilder().WithScheme(testScheme).Build(), Scheme: testScheme, Unstructured: true}
+	})
+
+	It("Should keep the fields the compiled types lack", func() {
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From:             appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+			ComponentSources: []appstudioredhatcomv1alpha1.ComponentSource{{Name: "c1"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Resources()).To(Equal([]appstudioredhatcomv1alpha1.Resource{
+			{Kind: "Application", Name: "appfoo"},
+			{Kind: "Component", Name: "c1"},
+		}))
+
+		application := plan.Objects[0].(*unstructured.Unstructured)
+		Expect(application.GetAPIVersion()).To(Equal("appstudio.redhat.com/v1alpha1"))
+		Expect(application.GetNamespace()).To(Equal("bar"))
+
+		component := plan.Objects[1].(*unstructured.Unstructured)
+		Expect(component.GetAPIVersion()).To(Equal("appstudio.redhat.com/v1alpha1"))
+		Expect(component.GetName()).To(Equal("c1"))
+		Expect(component.GetNamespace()).To(Equal("bar"))
+		Expect(component.GetUID()).To(BeEmpty())
+		Expect(component.GetAnnotations()).To(HaveKeyWithValue(SkipInitialChecksAnnotation, "true"))
+		Expect(component.Object).NotTo(HaveKey("status"))
+		Expect(component.Object["spec"]).To(Equal(map[string]interface{}{
+			"componentName": "c1",
+			"application":   "appfoo",
+			"source": map[string]interface{}{
+				"git": map[string]interface{}{"url": "github.com/foo/c1", "sparse": true},
+			},
+			"buildNudgesRef": []interface{}{"c2"},
+		}))
+	})
+
+	It("Should clone in the version chosen by the RESTMapper", func() {
+		gv := schema.GroupVersion{Group: "appstudio.redhat.com", Version: "v1beta1"}
+		mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{gv})
+		mapper.Add(gv.WithKind("Application"), meta.RESTScopeNamespace)
+		mapper.Add(gv.WithKind("Component"), meta.RESTScopeNamespace)
+		planner.RESTMapper = mapper
+
+		plan, err := planner.Plan(context.Background(), "bar", &appstudioredhatcomv1alpha1.ApplicationCloneSpec{
+			From: appstudioredhatcomv1alpha1.From{Namespace: "foo", Name: "appfoo"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		component := plan.Objects[1].(*unstructured.Unstructured)
+		Expect(component.GetAPIVersion()).To(Equal("appstudio.redhat.com/v1beta1"))
+		Expect(component.Object["spec"]).To(HaveKeyWithValue("containerImage", "quay.io/foo/c1:latest"))
+		Expect(component.Object["spec"]).To(HaveKeyWithValue("source", BeEmpty()))
+		Expect(component.Object["spec"]).To(HaveKey("buildNudgesRef"))
+		Expect(component.Object["spec"]).NotTo(HaveKey("route"))
+	})
+
+	It("Should compute and apply merge patches", func() {
+		original := map[string]interface{}{"a": "1", "b": map[string]interface{}{"c": "2", "d": "3"}, "e": []interface{}{"4"}}
+		modified := map[string]interface{}{"a": "1", "b": map[string]interface{}{"c": "5"}, "e": []interface{}{"4", "6"}, "f": "7"}
+		patch := mergePatch(original, modified)
+		Expect(patch).To(Equal(map[string]interface{}{"b": map[string]interface{}{"c": "5", "d": nil}, "e": []interface{}{"4", "6"}, "f": "7"}))
+
+		obj := map[string]interface{}{"a": "1", "b": map[string]interface{}{"c": "2", "d": "3", "g": "8"}, "e": []interface{}{"4"}, "h": "9"}
+		applyMergePatch(obj, patch)
+		Expect(obj).To(Equal(map[string]interface{}{"a": "1", "b": map[string]interface{}{"c": "5", "g": "8"}, "e": []interface{}{"4", "6"}, "f": "7", "h": "9"}))
+	})
+})